@@ -0,0 +1,289 @@
+package celoexplorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ABIArgument is an input or output of an ABI entry.
+type ABIArgument struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	InternalType string `json:"internalType,omitempty"`
+	// Only for event inputs.
+	Indexed bool `json:"indexed,omitempty"`
+	// Fields of a tuple type, in order.
+	Components []ABIArgument `json:"components,omitempty"`
+}
+
+// ABIEntry is one function, event, error, constructor, fallback or receive entry of a JSON ABI.
+type ABIEntry struct {
+	Type            string        `json:"type"`
+	Name            string        `json:"name,omitempty"`
+	Inputs          []ABIArgument `json:"inputs,omitempty"`
+	Outputs         []ABIArgument `json:"outputs,omitempty"`
+	StateMutability string        `json:"stateMutability,omitempty"`
+	// Only for events.
+	Anonymous bool `json:"anonymous,omitempty"`
+}
+
+// ABI is the interface of a contract as described by its JSON ABI. It marshals back to a JSON ABI.
+type ABI struct {
+	// Entries as they appear in the JSON ABI. The other fields are derived from them.
+	Entries     []ABIEntry
+	Constructor *Constructor
+	Methods     []Method
+	Events      []Event
+	Errors      []ABIError
+	// Whether the contract has a fallback or receive function.
+	Fallback bool
+	Receive  bool
+}
+
+type Constructor struct {
+	Inputs          []ABIArgument
+	StateMutability string
+}
+
+type Method struct {
+	Name string
+	// Canonical signature such as "transfer(address,uint256)".
+	Signature       string
+	Selector        [4]byte
+	Inputs          []ABIArgument
+	Outputs         []ABIArgument
+	StateMutability string
+}
+
+type Event struct {
+	Name string
+	// Canonical signature such as "Transfer(address,address,uint256)".
+	Signature string
+	// First topic of the logs of the event, unless it is anonymous.
+	Topic     Hash
+	Inputs    []ABIArgument
+	Anonymous bool
+}
+
+// ABIError is a custom error a contract can revert with.
+type ABIError struct {
+	Name      string
+	Signature string
+	Selector  [4]byte
+	Inputs    []ABIArgument
+}
+
+// Parse a JSON ABI.
+func ParseABI(s string) (*ABI, error) {
+	var entries []ABIEntry
+	if err := json.Unmarshal([]byte(s), &entries); err != nil {
+		return nil, fmt.Errorf("celoexplorer: invalid abi: %w", err)
+	}
+
+	abi := &ABI{Entries: entries}
+	for i := range entries {
+		e := &entries[i]
+		// entries without a type are functions
+		if e.Type == "" {
+			e.Type = "function"
+		}
+
+		switch e.Type {
+		case "function":
+			signature := Signature(e.Name, e.Inputs)
+			abi.Methods = append(abi.Methods, Method{
+				Name:            e.Name,
+				Signature:       signature,
+				Selector:        selector(signature),
+				Inputs:          e.Inputs,
+				Outputs:         e.Outputs,
+				StateMutability: e.StateMutability,
+			})
+		case "event":
+			signature := Signature(e.Name, e.Inputs)
+			abi.Events = append(abi.Events, Event{
+				Name:      e.Name,
+				Signature: signature,
+				Topic:     keccak256([]byte(signature)),
+				Inputs:    e.Inputs,
+				Anonymous: e.Anonymous,
+			})
+		case "error":
+			signature := Signature(e.Name, e.Inputs)
+			abi.Errors = append(abi.Errors, ABIError{
+				Name:      e.Name,
+				Signature: signature,
+				Selector:  selector(signature),
+				Inputs:    e.Inputs,
+			})
+		case "constructor":
+			abi.Constructor = &Constructor{
+				Inputs:          e.Inputs,
+				StateMutability: e.StateMutability,
+			}
+		case "fallback":
+			abi.Fallback = true
+		case "receive":
+			abi.Receive = true
+		default:
+			return nil, fmt.Errorf("celoexplorer: invalid abi: entry %d has unknown type %q", i, e.Type)
+		}
+	}
+	return abi, nil
+}
+
+// Canonical signature of a function, event or error, as hashed for selectors and topics.
+// Tuples are spelled out as their component types in parentheses.
+func Signature(name string, inputs []ABIArgument) string {
+	return name + "(" + canonicalTypes(inputs) + ")"
+}
+
+func canonicalTypes(args []ABIArgument) string {
+	types := make([]string, len(args))
+	for i, a := range args {
+		types[i] = canonicalType(a)
+	}
+	return strings.Join(types, ",")
+}
+
+func canonicalType(arg ABIArgument) string {
+	typ := arg.Type
+	if strings.HasPrefix(typ, "tuple") {
+		// keep the array suffix, if any
+		return "(" + canonicalTypes(arg.Components) + ")" + strings.TrimPrefix(typ, "tuple")
+	}
+
+	// uint and int are aliases of uint256 and int256
+	for _, alias := range []string{"uint", "int"} {
+		if typ == alias || strings.HasPrefix(typ, alias+"[") {
+			return alias + "256" + strings.TrimPrefix(typ, alias)
+		}
+	}
+	return typ
+}
+
+func selector(signature string) [4]byte {
+	var s [4]byte
+	hash := keccak256([]byte(signature))
+	copy(s[:], hash[:4])
+	return s
+}
+
+// Method with selector, the first 4 bytes of call data.
+func (a *ABI) MethodBySelector(selector [4]byte) (Method, bool) {
+	for _, m := range a.Methods {
+		if m.Selector == selector {
+			return m, true
+		}
+	}
+	return Method{}, false
+}
+
+// First method called name. Overloaded methods share a name; use MethodBySelector to tell them apart.
+func (a *ABI) MethodByName(name string) (Method, bool) {
+	for _, m := range a.Methods {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Method{}, false
+}
+
+// Event whose logs have topic as their first topic.
+func (a *ABI) EventByTopic(topic Hash) (Event, bool) {
+	for _, e := range a.Events {
+		if !e.Anonymous && e.Topic == topic {
+			return e, true
+		}
+	}
+	return Event{}, false
+}
+
+// Error with selector, the first 4 bytes of revert data.
+func (a *ABI) ErrorBySelector(selector [4]byte) (ABIError, bool) {
+	for _, e := range a.Errors {
+		if e.Selector == selector {
+			return e, true
+		}
+	}
+	return ABIError{}, false
+}
+
+// Parsed ABIs by contract address, nil for contracts that are not verified. Contracts cannot change their code, so entries never expire.
+type abiCache struct {
+	mu   sync.Mutex
+	abis map[Address]*ABI
+}
+
+func (c *abiCache) get(address Address) (*ABI, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	abi, ok := c.abis[address]
+	return abi, ok
+}
+
+func (c *abiCache) put(address Address, abi *ABI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.abis == nil {
+		c.abis = make(map[Address]*ABI)
+	}
+	c.abis[address] = abi
+}
+
+// Get the parsed ABI of a verified contract. ABIs are cached per address for the life of the client, so the result is shared and must not be modified.
+// Fails with ErrNotFound if the contract is not verified. That is cached as well, so a contract verified later is only seen by a new client.
+func (c *Client) ContractABI(address Address) (*ABI, error) {
+	return c.ContractABICtx(context.Background(), address)
+}
+
+// ContractABICtx is ContractABI with a context.
+func (c *Client) ContractABICtx(ctx context.Context, address Address) (*ABI, error) {
+	if abi, ok := c.abis.get(address); ok {
+		if abi == nil {
+			return nil, fmt.Errorf("%w: no abi for %s", ErrNotFound, address)
+		}
+		return abi, nil
+	}
+
+	text, err := c.req.GetAbiCtx(ctx, address)
+	if errors.Is(err, ErrNotFound) {
+		c.abis.put(address, nil)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("GetAbi")
+	abi := d.abi("result", string(text))
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	c.abis.put(address, abi)
+	if abi == nil {
+		return nil, fmt.Errorf("%w: no abi for %s", ErrNotFound, address)
+	}
+	return abi, nil
+}
+
+func (a ABI) MarshalJSON() ([]byte, error) {
+	if a.Entries == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(a.Entries)
+}
+
+func (a *ABI) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseABI(string(data))
+	if err != nil {
+		return err
+	}
+	*a = *parsed
+	return nil
+}
@@ -0,0 +1,91 @@
+package celoexplorer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const erc20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"batch","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"data","type":"bytes"}]},{"name":"n","type":"uint"}]},
+	{"type":"receive","stateMutability":"payable"}
+]`
+
+func TestParseABI(t *testing.T) {
+	abi, err := ParseABI(erc20ABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	methods := map[string]string{
+		"transfer(address,uint256)":        "a9059cbb",
+		"batch((address,bytes)[],uint256)": "2994e812",
+	}
+	for _, m := range abi.Methods {
+		want, ok := methods[m.Signature]
+		if !ok {
+			t.Errorf("unexpected method %s", m.Signature)
+		}
+		if fmt.Sprintf("%x", m.Selector) != want {
+			t.Errorf("%s: got selector %x, want %s", m.Signature, m.Selector, want)
+		}
+	}
+
+	event, ok := abi.EventByTopic(MustParseHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"))
+	if !ok || event.Signature != "Transfer(address,address,uint256)" {
+		t.Errorf("got event %+v, %v", event, ok)
+	}
+	if len(abi.Errors) != 1 || abi.Errors[0].Signature != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("got errors %+v", abi.Errors)
+	}
+	if abi.Constructor == nil || len(abi.Constructor.Inputs) != 1 || !abi.Receive || abi.Fallback {
+		t.Errorf("got constructor %+v, receive %v, fallback %v", abi.Constructor, abi.Receive, abi.Fallback)
+	}
+
+	if _, err := ParseABI(`[{"type":"modifier"}]`); err == nil {
+		t.Error("unknown entry type was accepted")
+	}
+}
+
+func TestContractABICache(t *testing.T) {
+	verified := MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438")
+	unverified := MustParseAddress("0x765de816845861e75a25fca122bb6898b8b1282a")
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if strings.EqualFold(req.URL.Query().Get("address"), verified.Hex()) {
+			fmt.Fprintf(w, `{"message":"OK","result":%q,"status":"1"}`, erc20ABI)
+			return
+		}
+		fmt.Fprint(w, `{"message":"Contract source code not verified","result":null,"status":"0"}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		abi, err := c.ContractABI(verified)
+		if err != nil || len(abi.Methods) != 2 {
+			t.Fatalf("verified: got %v, %v", abi, err)
+		}
+		if _, err := c.ContractABI(unverified); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("unverified: got error %v, want ErrNotFound", err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
@@ -0,0 +1,290 @@
+package celoexplorer
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+type Account struct {
+	Address Address  `json:"address"`
+	Balance *big.Int `json:"balance"`
+}
+
+// Get a list of accounts and their balances, sorted ascending by the time they were first seen by the explorer.
+func (c *Client) ListAccounts(page *PageRange) ([]Account, error) {
+	return c.ListAccountsCtx(context.Background(), page)
+}
+
+// ListAccountsCtx is ListAccounts with a context.
+func (c *Client) ListAccountsCtx(ctx context.Context, page *PageRange) ([]Account, error) {
+	accountList, err := c.req.ListAccountsCtx(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("ListAccounts")
+	accounts := make([]Account, len(accountList))
+	for i, v := range accountList {
+		d.at(i)
+		accounts[i].Address = d.address("address", v.Address)
+		accounts[i].Balance = d.bigInt("balance", v.Balance, 10)
+	}
+	return accounts, d.err
+}
+
+type CrawlOptions struct {
+	// Accounts per page, 1,000 when not positive. Ignored when resuming from a checkpoint, which keeps the page size it was started with.
+	PageSize int
+	// Pages to fetch before stopping, no limit when not positive.
+	MaxPages int
+	// Richest accounts to keep track of, none when not positive.
+	Top int
+	// File the crawl position and the richest accounts are saved to before every page, and resumed from when it exists.
+	// No checkpoints are kept when empty.
+	CheckpointFile string
+}
+
+// Content of a checkpoint file.
+type crawlCheckpoint struct {
+	// Next page to fetch.
+	Page     int       `json:"page"`
+	PageSize int       `json:"pageSize"`
+	Top      []Account `json:"top,omitempty"`
+}
+
+// AccountCrawler walks through every account the explorer has seen, fetching one page at a time and keeping the richest accounts.
+type AccountCrawler struct {
+	pager
+	items    []Account
+	cur      Account
+	top      *richList
+	file     string
+	complete bool
+	saved    bool
+}
+
+// Crawl the accounts seen by the explorer, oldest first. Resumes from opts.CheckpointFile when it exists.
+// Because accounts are listed in the order they were first seen, a finished crawl can be resumed later to pick up the accounts seen since.
+func (c *Client) CrawlAccounts(ctx context.Context, opts CrawlOptions) (*AccountCrawler, error) {
+	cp := crawlCheckpoint{Page: 1, PageSize: opts.PageSize}
+	if opts.CheckpointFile != "" {
+		loaded, err := loadCheckpoint(opts.CheckpointFile)
+		if err != nil {
+			return nil, err
+		}
+		if loaded != nil {
+			cp = *loaded
+		}
+	}
+
+	it := &AccountCrawler{
+		top:  newRichList(opts.Top),
+		file: opts.CheckpointFile,
+	}
+	for _, a := range cp.Top {
+		it.top.add(a)
+	}
+
+	var fetched int
+	it.pager = newPager(ctx, cp.PageSize, func(ctx context.Context, page PageRange) (int, error) {
+		// everything before page has been consumed by now
+		if err := it.save(page); err != nil {
+			return 0, err
+		}
+		if opts.MaxPages > 0 && fetched >= opts.MaxPages {
+			return 0, nil
+		}
+		fetched++
+
+		accounts, err := c.ListAccountsCtx(ctx, &page)
+		it.items = accounts
+		if err == nil && len(accounts) < page.Offset {
+			it.complete = true
+		}
+		return len(accounts), err
+	})
+	it.pager.page = cp.Page
+	return it, nil
+}
+
+// Advance to the next account. Returns false when there are no more accounts, the page budget is spent or an error occurred.
+func (it *AccountCrawler) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+		it.top.add(it.cur)
+		return true
+	}
+
+	if it.complete && it.err == nil && !it.saved {
+		it.saved = true
+		// keep the last page, which was short, so that resuming picks up the accounts added to it since
+		it.err = it.save(PageRange{Page: it.page - 1, Offset: it.size})
+	}
+	return false
+}
+
+// Current account.
+func (it *AccountCrawler) Value() Account {
+	return it.cur
+}
+
+// Whether the crawl reached the last account rather than stopping at the page budget or on an error.
+func (it *AccountCrawler) Complete() bool {
+	return it.complete && it.err == nil
+}
+
+// Richest accounts seen so far, including those of earlier runs resumed from, richest first.
+func (it *AccountCrawler) RichList() []Account {
+	return it.top.sorted()
+}
+
+func (it *AccountCrawler) save(page PageRange) error {
+	if it.file == "" {
+		return nil
+	}
+	return saveCheckpoint(it.file, crawlCheckpoint{Page: page.Page, PageSize: page.Offset, Top: it.top.sorted()})
+}
+
+// Crawl the accounts seen by the explorer and return the top richest, richest first. See CrawlAccounts for the options.
+func (c *Client) RichList(top int, opts CrawlOptions) ([]Account, error) {
+	return c.RichListCtx(context.Background(), top, opts)
+}
+
+// RichListCtx is RichList with a context.
+func (c *Client) RichListCtx(ctx context.Context, top int, opts CrawlOptions) ([]Account, error) {
+	opts.Top = top
+	it, err := c.CrawlAccounts(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return it.RichList(), nil
+}
+
+// Checkpoint in file, nil if there is none yet.
+func loadCheckpoint(file string) (*crawlCheckpoint, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cp crawlCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("celoexplorer: checkpoint %s: %w", file, err)
+	}
+	if cp.Page < 1 || cp.PageSize < 1 {
+		return nil, fmt.Errorf("celoexplorer: checkpoint %s: invalid page %d of size %d", file, cp.Page, cp.PageSize)
+	}
+	return &cp, nil
+}
+
+// Write the checkpoint next to file and move it in place, so that a crash never leaves a partial checkpoint behind.
+func saveCheckpoint(file string, cp crawlCheckpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// Richest accounts seen, as a min-heap on balance so the poorest of them is dropped first.
+// An account seen again replaces its earlier balance.
+type richList struct {
+	size     int
+	accounts []Account
+	index    map[Address]int
+}
+
+func newRichList(size int) *richList {
+	return &richList{
+		size:  size,
+		index: make(map[Address]int),
+	}
+}
+
+func (l *richList) Len() int { return len(l.accounts) }
+
+func (l *richList) Less(i, j int) bool {
+	return compareBigInt(l.accounts[i].Balance, l.accounts[j].Balance) < 0
+}
+
+func (l *richList) Swap(i, j int) {
+	l.accounts[i], l.accounts[j] = l.accounts[j], l.accounts[i]
+	l.index[l.accounts[i].Address] = i
+	l.index[l.accounts[j].Address] = j
+}
+
+func (l *richList) Push(x interface{}) {
+	a := x.(Account)
+	l.index[a.Address] = len(l.accounts)
+	l.accounts = append(l.accounts, a)
+}
+
+func (l *richList) Pop() interface{} {
+	last := l.accounts[len(l.accounts)-1]
+	l.accounts = l.accounts[:len(l.accounts)-1]
+	delete(l.index, last.Address)
+	return last
+}
+
+func (l *richList) add(a Account) {
+	if l.size <= 0 || a.Balance == nil {
+		return
+	}
+
+	if i, ok := l.index[a.Address]; ok {
+		l.accounts[i].Balance = a.Balance
+		heap.Fix(l, i)
+		return
+	}
+
+	if len(l.accounts) < l.size {
+		heap.Push(l, a)
+		return
+	}
+	if compareBigInt(a.Balance, l.accounts[0].Balance) > 0 {
+		delete(l.index, l.accounts[0].Address)
+		l.accounts[0] = a
+		l.index[a.Address] = 0
+		heap.Fix(l, 0)
+	}
+}
+
+func (l *richList) sorted() []Account {
+	accounts := make([]Account, len(l.accounts))
+	copy(accounts, l.accounts)
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return compareBigInt(accounts[i].Balance, accounts[j].Balance) > 0
+	})
+	return accounts
+}
@@ -0,0 +1,134 @@
+package celoexplorer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Address is a 20 byte account or contract address. Addresses can be compared with ==.
+// It marshals to text and JSON in its EIP-55 checksummed form.
+type Address [20]byte
+
+// Hash is a 32 byte transaction hash, block hash or log topic. Hashes can be compared with ==.
+type Hash [32]byte
+
+// Parse a hex address, with or without 0x.
+// All lowercase and all uppercase addresses are accepted as is; mixed case addresses must carry a valid EIP-55 checksum.
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	text := trim0x(s)
+	if len(text) != 2*len(a) {
+		return Address{}, fmt.Errorf("celoexplorer: invalid address %q: want %d hex digits, got %d", s, 2*len(a), len(text))
+	}
+
+	if _, err := hex.Decode(a[:], []byte(text)); err != nil {
+		return Address{}, fmt.Errorf("celoexplorer: invalid address %q: %v", s, err)
+	}
+
+	if text != strings.ToLower(text) && text != strings.ToUpper(text) && a.Hex() != "0x"+text {
+		return Address{}, fmt.Errorf("celoexplorer: invalid address %q: bad EIP-55 checksum", s)
+	}
+	return a, nil
+}
+
+// Same as ParseAddress but panics on error. Meant for constants.
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// EIP-55 checksummed hex form with 0x.
+func (a Address) Hex() string {
+	lower := hex.EncodeToString(a[:])
+	hash := keccak256([]byte(lower))
+
+	result := []byte(lower)
+	for i, c := range result {
+		if c < 'a' {
+			continue
+		}
+		// uppercase the letter if the matching nibble of the hash is 8 or more
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			result[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(result)
+}
+
+func (a Address) String() string {
+	return a.Hex()
+}
+
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Parse a hex hash, with or without 0x.
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	text := trim0x(s)
+	if len(text) != 2*len(h) {
+		return Hash{}, fmt.Errorf("celoexplorer: invalid hash %q: want %d hex digits, got %d", s, 2*len(h), len(text))
+	}
+
+	if _, err := hex.Decode(h[:], []byte(text)); err != nil {
+		return Hash{}, fmt.Errorf("celoexplorer: invalid hash %q: %v", s, err)
+	}
+	return h, nil
+}
+
+// Same as ParseHash but panics on error. Meant for constants.
+func MustParseHash(s string) Hash {
+	h, err := ParseHash(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// Lowercase hex form with 0x.
+func (h Hash) Hex() string {
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+func (h Hash) IsZero() bool {
+	return h == Hash{}
+}
+
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(h.Hex()), nil
+}
+
+func (h *Hash) UnmarshalText(text []byte) error {
+	parsed, err := ParseHash(string(text))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
@@ -0,0 +1,116 @@
+package celoexplorer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Examples from EIP-55.
+var eip55Addresses = []string{
+	"0x52908400098527886E0F7030069857D2E4169EE7",
+	"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+	"0xde709f2102306220921060314715629080e2fb77",
+	"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestAddressHex(t *testing.T) {
+	for _, want := range eip55Addresses {
+		t.Run(want, func(t *testing.T) {
+			for _, input := range []string{want, strings.ToLower(want), "0x" + strings.ToUpper(want[2:]), want[2:]} {
+				a, err := ParseAddress(input)
+				if err != nil {
+					t.Fatalf("ParseAddress(%q): %v", input, err)
+				}
+				if a.Hex() != want {
+					t.Errorf("ParseAddress(%q).Hex() = %s, want %s", input, a.Hex(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAddressInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		// last letter of 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed in the wrong case
+		{"bad checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"},
+		{"short", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA"},
+		{"long", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00"},
+		{"not hex", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg"},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a, err := ParseAddress(tt.input); err == nil {
+				t.Errorf("ParseAddress(%q) = %s, want error", tt.input, a)
+			}
+		})
+	}
+}
+
+func TestAddressRoundTrip(t *testing.T) {
+	want := MustParseAddress("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+
+	text, err := want.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromText Address
+	if err := fromText.UnmarshalText(text); err != nil || fromText != want {
+		t.Errorf("text round trip of %s gave %s, %v", want, fromText, err)
+	}
+
+	data, err := json.Marshal(map[string]Address{"a": want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"}` {
+		t.Errorf("got json %s", data)
+	}
+	var fromJSON map[string]Address
+	if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON["a"] != want {
+		t.Errorf("json round trip of %s gave %s, %v", want, fromJSON["a"], err)
+	}
+
+	var bad Address
+	if err := json.Unmarshal([]byte(`"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"`), &bad); err == nil {
+		t.Error("json with a bad checksum was accepted")
+	}
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	const text = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	want := MustParseHash(text)
+	if want.Hex() != text {
+		t.Errorf("Hex() = %s, want %s", want.Hex(), text)
+	}
+
+	upper, err := ParseHash("0x" + strings.ToUpper(text[2:]))
+	if err != nil || upper != want {
+		t.Errorf("uppercase hash parsed to %s, %v", upper, err)
+	}
+
+	data, err := json.Marshal([]Hash{want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["`+text+`"]` {
+		t.Errorf("got json %s", data)
+	}
+	var fromJSON []Hash
+	if err := json.Unmarshal(data, &fromJSON); err != nil || len(fromJSON) != 1 || fromJSON[0] != want {
+		t.Errorf("json round trip of %s gave %v, %v", want, fromJSON, err)
+	}
+
+	if _, err := ParseHash(text[:64]); err == nil {
+		t.Error("short hash was accepted")
+	}
+}
@@ -0,0 +1,182 @@
+package celoexplorer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Number of blocks in a Celo epoch. Epoch rewards are paid out in the last block of every epoch.
+const EpochSize = 17280
+
+// Epoch of a block. Block 0 is in epoch 0 and epoch n spans the EpochSize blocks ending at n * EpochSize.
+func EpochOf(blockNumber uint64) uint64 {
+	if blockNumber%EpochSize == 0 {
+		return blockNumber / EpochSize
+	}
+	return blockNumber/EpochSize + 1
+}
+
+type MinedBlock struct {
+	BlockNumber *big.Int
+	Reward      *big.Int
+	Timestamp   time.Time
+}
+
+// Get list of blocks mined by address. Up to a maximum of 10,000 blocks per page.
+func (c *Client) MinedBlocks(signer Address, page *PageRange) ([]MinedBlock, error) {
+	return c.MinedBlocksCtx(context.Background(), signer, page)
+}
+
+// MinedBlocksCtx is MinedBlocks with a context.
+func (c *Client) MinedBlocksCtx(ctx context.Context, signer Address, page *PageRange) ([]MinedBlock, error) {
+	blockList, err := c.req.GetMinedBlocksCtx(ctx, signer, page)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("GetMinedBlocks")
+	blocks := make([]MinedBlock, len(blockList))
+	for i, v := range blockList {
+		d.at(i)
+		blocks[i].BlockNumber = d.bigInt("blockNumber", v.Blocknumber, 10)
+		blocks[i].Reward = d.bigInt("blockReward", v.Blockreward, 10)
+		blocks[i].Timestamp = d.time("timeStamp", v.Timestamp)
+	}
+	return blocks, d.err
+}
+
+type UncleReward struct {
+	Miner    Address
+	Position int
+	Reward   *big.Int
+}
+
+type BlockReward struct {
+	BlockMiner           Address
+	BlockNumber          *big.Int
+	Reward               *big.Int
+	Timestamp            time.Time
+	UncleInclusionReward *big.Int
+	// Always empty on Celo, which has no uncles.
+	Uncles []UncleReward
+}
+
+// Get block reward by block number.
+func (c *Client) BlockReward(blockNumber *big.Int) (BlockReward, error) {
+	return c.BlockRewardCtx(context.Background(), blockNumber)
+}
+
+// BlockRewardCtx is BlockReward with a context.
+func (c *Client) BlockRewardCtx(ctx context.Context, blockNumber *big.Int) (BlockReward, error) {
+	reward, err := c.req.GetBlockRewardCtx(ctx, blockNumber)
+	if err != nil {
+		return BlockReward{}, err
+	}
+
+	d := c.decoder("GetBlockReward")
+	uncles := make([]UncleReward, len(reward.Uncles))
+	for i, v := range reward.Uncles {
+		field := fmt.Sprintf("uncles[%d].", i)
+		uncles[i].Miner = d.address(field+"miner", v.Miner)
+		uncles[i].Position = d.int(field+"unclePosition", v.UnclePosition, 10)
+		uncles[i].Reward = d.bigInt(field+"blockreward", v.Blockreward, 10)
+	}
+
+	return BlockReward{
+		BlockMiner:           d.address("blockMiner", reward.Blockminer),
+		BlockNumber:          d.bigInt("blockNumber", reward.Blocknumber, 10),
+		Reward:               d.bigInt("blockReward", reward.Blockreward, 10),
+		Timestamp:            d.time("timeStamp", reward.Timestamp),
+		UncleInclusionReward: d.optional().bigInt("uncleInclusionReward", reward.Uncleinclusionreward, 10),
+		Uncles:               uncles,
+	}, d.err
+}
+
+// RewardPeriod totals the blocks a signer mined in a day or an epoch.
+type RewardPeriod struct {
+	// Midnight UTC starting the day. Zero for epochs.
+	Day time.Time
+	// Zero for days.
+	Epoch      uint64
+	FirstBlock *big.Int
+	LastBlock  *big.Int
+	Blocks     int
+	Reward     *big.Int
+}
+
+// RewardSummary totals the block rewards of a signer.
+type RewardSummary struct {
+	Signer Address
+	Blocks int
+	Reward *big.Int
+	// Oldest first. Days and epochs without blocks are left out.
+	Days   []RewardPeriod
+	Epochs []RewardPeriod
+}
+
+// Walk through every block mined by signer and total the block rewards per UTC day and per epoch.
+// Only rewards paid with the mined blocks are counted; validator and voter epoch rewards are not.
+func (c *Client) MinedBlockRewards(signer Address) (RewardSummary, error) {
+	return c.MinedBlockRewardsCtx(context.Background(), signer)
+}
+
+// MinedBlockRewardsCtx is MinedBlockRewards with a context.
+func (c *Client) MinedBlockRewardsCtx(ctx context.Context, signer Address) (RewardSummary, error) {
+	var blocks []MinedBlock
+	it := c.MinedBlocksIterator(ctx, signer, 0)
+	for it.Next() {
+		blocks = append(blocks, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return RewardSummary{}, err
+	}
+
+	return summarizeRewards(signer, blocks), nil
+}
+
+func summarizeRewards(signer Address, blocks []MinedBlock) RewardSummary {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return compareBigInt(blocks[i].BlockNumber, blocks[j].BlockNumber) < 0
+	})
+
+	summary := RewardSummary{
+		Signer: signer,
+		Reward: new(big.Int),
+	}
+	var day, epoch *RewardPeriod
+	for _, b := range blocks {
+		if b.BlockNumber == nil {
+			continue
+		}
+		reward := b.Reward
+		if reward == nil {
+			reward = new(big.Int)
+		}
+		summary.Blocks++
+		summary.Reward.Add(summary.Reward, reward)
+
+		start := b.Timestamp.UTC().Truncate(24 * time.Hour)
+		if day == nil || !day.Day.Equal(start) {
+			summary.Days = append(summary.Days, RewardPeriod{Day: start, FirstBlock: b.BlockNumber, Reward: new(big.Int)})
+			day = &summary.Days[len(summary.Days)-1]
+		}
+		day.add(b.BlockNumber, reward)
+
+		n := EpochOf(b.BlockNumber.Uint64())
+		if epoch == nil || epoch.Epoch != n {
+			summary.Epochs = append(summary.Epochs, RewardPeriod{Epoch: n, FirstBlock: b.BlockNumber, Reward: new(big.Int)})
+			epoch = &summary.Epochs[len(summary.Epochs)-1]
+		}
+		epoch.add(b.BlockNumber, reward)
+	}
+	return summary
+}
+
+func (p *RewardPeriod) add(blockNumber, reward *big.Int) {
+	p.LastBlock = blockNumber
+	p.Blocks++
+	p.Reward.Add(p.Reward, reward)
+}
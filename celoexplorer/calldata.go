@@ -0,0 +1,392 @@
+package celoexplorer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Returned when call data does not match any method the client knows of.
+var ErrUnknownMethod = errors.New("celoexplorer: unknown method")
+
+// CallData is a call to a contract, such as a Transaction, TransactionWithLogs, PendingTransaction or InternalTransaction.
+type CallData interface {
+	// Contract called, zero for contract creations.
+	CallTo() Address
+	// Selector followed by the ABI encoded arguments.
+	CallInput() []byte
+}
+
+func (t Transaction) CallTo() Address           { return t.To }
+func (t Transaction) CallInput() []byte         { return t.Input }
+func (t TransactionWithLogs) CallTo() Address   { return t.To }
+func (t TransactionWithLogs) CallInput() []byte { return t.Input }
+func (t PendingTransaction) CallTo() Address    { return t.To }
+func (t PendingTransaction) CallInput() []byte  { return t.Input }
+func (t InternalTransaction) CallTo() Address   { return t.To }
+func (t InternalTransaction) CallInput() []byte { return t.Input }
+
+// DecodedCall is call data matched to a method and split into its arguments.
+type DecodedCall struct {
+	Method Method
+	Args   []DecodedArg
+	// Whether the method comes from the verified ABI of the contract rather than from the selector database.
+	FromABI bool
+}
+
+// DecodedArg is one decoded argument. Value is
+//   - Address for address
+//   - *big.Int for intN and uintN
+//   - bool for bool
+//   - []byte for bytes and bytesN
+//   - string for string
+//   - []interface{} for arrays, holding values of these kinds
+//   - []DecodedArg for tuples
+type DecodedArg struct {
+	// Empty when the method comes from the selector database, which knows no names.
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// Decode the input of a call into its method and arguments.
+// The method is looked up in the ABI of the contract called, as given by ContractABI, and then in the selector database, which covers common token and Celo core contract methods as well as those added with RegisterSignatures.
+// Fails with ErrUnknownMethod when neither knows the selector, or the call has no call data.
+func (c *Client) DecodeInput(tx CallData) (DecodedCall, error) {
+	return c.DecodeInputCtx(context.Background(), tx)
+}
+
+// DecodeInputCtx is DecodeInput with a context.
+func (c *Client) DecodeInputCtx(ctx context.Context, tx CallData) (DecodedCall, error) {
+	input := tx.CallInput()
+	if tx.CallTo().IsZero() {
+		return DecodedCall{}, fmt.Errorf("%w: contract creation", ErrUnknownMethod)
+	}
+	if len(input) < 4 {
+		return DecodedCall{}, fmt.Errorf("%w: no call data", ErrUnknownMethod)
+	}
+
+	var sel [4]byte
+	copy(sel[:], input)
+
+	call := DecodedCall{}
+	abi, err := c.ContractABICtx(ctx, tx.CallTo())
+	switch {
+	case err == nil:
+		call.Method, call.FromABI = abi.MethodBySelector(sel)
+	case !errors.Is(err, ErrNotFound):
+		return DecodedCall{}, err
+	}
+
+	if !call.FromABI {
+		// unverified contracts and proxies, whose ABI lacks the methods of the implementation
+		method, ok := lookupSelector(sel)
+		if !ok {
+			return DecodedCall{}, fmt.Errorf("%w: selector 0x%x on %s", ErrUnknownMethod, sel, tx.CallTo())
+		}
+		call.Method = method
+	}
+
+	call.Args, err = DecodeArguments(call.Method.Inputs, input[4:])
+	if err != nil {
+		return DecodedCall{}, fmt.Errorf("%w: %s: %v", ErrDecode, call.Method.Signature, err)
+	}
+	return call, nil
+}
+
+// Decode ABI encoded data, such as call data after the selector or the data of a log, into the values of args.
+func DecodeArguments(args []ABIArgument, data []byte) ([]DecodedArg, error) {
+	types, err := newABITypes(args)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decodeTuple(types, data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]DecodedArg, len(args))
+	for i, a := range args {
+		decoded[i] = DecodedArg{Name: a.Name, Type: canonicalType(a), Value: values[i]}
+	}
+	return decoded, nil
+}
+
+type abiKind int
+
+const (
+	abiUint abiKind = iota
+	abiInt
+	abiAddress
+	abiBool
+	abiFixedBytes
+	abiBytes
+	abiString
+	// T[k]
+	abiArray
+	// T[]
+	abiSlice
+	abiTuple
+)
+
+// Parsed type of an argument.
+type abiType struct {
+	kind abiKind
+	// Bits of integers, bytes of bytesN and length of fixed arrays.
+	size int
+	// Element of arrays.
+	elem *abiType
+	// Fields of tuples.
+	fields []ABIArgument
+	types  []*abiType
+}
+
+func newABITypes(args []ABIArgument) ([]*abiType, error) {
+	types := make([]*abiType, len(args))
+	for i, a := range args {
+		t, err := newABIType(a.Type, a.Components)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+	return types, nil
+}
+
+func newABIType(typ string, components []ABIArgument) (*abiType, error) {
+	if strings.HasSuffix(typ, "]") {
+		open := strings.LastIndex(typ, "[")
+		if open < 0 {
+			return nil, fmt.Errorf("invalid type %q", typ)
+		}
+
+		elem, err := newABIType(typ[:open], components)
+		if err != nil {
+			return nil, err
+		}
+
+		length := typ[open+1 : len(typ)-1]
+		if length == "" {
+			return &abiType{kind: abiSlice, elem: elem}, nil
+		}
+		n, err := strconv.Atoi(length)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid array length in type %q", typ)
+		}
+		return &abiType{kind: abiArray, size: n, elem: elem}, nil
+	}
+
+	switch {
+	case typ == "tuple":
+		types, err := newABITypes(components)
+		if err != nil {
+			return nil, err
+		}
+		return &abiType{kind: abiTuple, fields: components, types: types}, nil
+	case typ == "address":
+		return &abiType{kind: abiAddress}, nil
+	case typ == "bool":
+		return &abiType{kind: abiBool}, nil
+	case typ == "string":
+		return &abiType{kind: abiString}, nil
+	case typ == "bytes":
+		return &abiType{kind: abiBytes}, nil
+	case typ == "function":
+		// an address followed by a selector
+		return &abiType{kind: abiFixedBytes, size: 24}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("invalid type %q", typ)
+		}
+		return &abiType{kind: abiFixedBytes, size: n}, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		kind, bits := abiUint, strings.TrimPrefix(typ, "uint")
+		if strings.HasPrefix(typ, "int") {
+			kind, bits = abiInt, strings.TrimPrefix(typ, "int")
+		}
+		if bits == "" {
+			bits = "256"
+		}
+		n, err := strconv.Atoi(bits)
+		if err != nil || n < 8 || n > 256 || n%8 != 0 {
+			return nil, fmt.Errorf("invalid type %q", typ)
+		}
+		return &abiType{kind: kind, size: n}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", typ)
+}
+
+// Whether values of the type are encoded out of place, behind an offset.
+func (t *abiType) dynamic() bool {
+	switch t.kind {
+	case abiBytes, abiString, abiSlice:
+		return true
+	case abiArray:
+		return t.size > 0 && t.elem.dynamic()
+	case abiTuple:
+		for _, f := range t.types {
+			if f.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Bytes the type takes in the head of the enclosing tuple.
+func (t *abiType) headSize() int {
+	if t.dynamic() {
+		return 32
+	}
+
+	switch t.kind {
+	case abiArray:
+		return t.size * t.elem.headSize()
+	case abiTuple:
+		var size int
+		for _, f := range t.types {
+			size += f.headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+// Values of types encoded one after the other, dynamic ones at an offset from the start of data.
+func decodeTuple(types []*abiType, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	var pos int
+	for i, t := range types {
+		at := pos
+		if t.dynamic() {
+			offset, err := readLength(data, pos)
+			if err != nil {
+				return nil, err
+			}
+			at = offset
+		}
+
+		v, err := decodeValue(t, data, at)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+		pos += t.headSize()
+	}
+	return values, nil
+}
+
+func decodeValue(t *abiType, data []byte, at int) (interface{}, error) {
+	if at < 0 || at > len(data) {
+		return nil, fmt.Errorf("offset %d out of range", at)
+	}
+
+	switch t.kind {
+	case abiArray, abiSlice:
+		n := t.size
+		if t.kind == abiSlice {
+			length, err := readLength(data, at)
+			if err != nil {
+				return nil, err
+			}
+			n, at = length, at+32
+		}
+		// every element takes at least a word, which bounds n before allocating
+		if n > (len(data)-at)/32 && t.elem.headSize() > 0 {
+			return nil, fmt.Errorf("array length %d out of range", n)
+		}
+
+		types := make([]*abiType, n)
+		for i := range types {
+			types[i] = t.elem
+		}
+		return decodeTuple(types, data[at:])
+	case abiTuple:
+		values, err := decodeTuple(t.types, data[at:])
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]DecodedArg, len(values))
+		for i, f := range t.fields {
+			fields[i] = DecodedArg{Name: f.Name, Type: canonicalType(f), Value: values[i]}
+		}
+		return fields, nil
+	case abiBytes, abiString:
+		length, err := readLength(data, at)
+		if err != nil {
+			return nil, err
+		}
+		if length > len(data)-at-32 {
+			return nil, fmt.Errorf("length %d out of range", length)
+		}
+		b := make([]byte, length)
+		copy(b, data[at+32:])
+		if t.kind == abiString {
+			return string(b), nil
+		}
+		return b, nil
+	}
+
+	word, err := readWord(data, at)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case abiUint:
+		return new(big.Int).SetBytes(word), nil
+	case abiInt:
+		n := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			// two's complement
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return n, nil
+	case abiAddress:
+		var a Address
+		copy(a[:], word[12:])
+		return a, nil
+	case abiBool:
+		if word[31] > 1 {
+			return nil, fmt.Errorf("invalid bool")
+		}
+		return word[31] == 1, nil
+	case abiFixedBytes:
+		b := make([]byte, t.size)
+		copy(b, word)
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported type")
+}
+
+func readWord(data []byte, at int) ([]byte, error) {
+	if at < 0 || at+32 > len(data) {
+		return nil, fmt.Errorf("word at %d out of range", at)
+	}
+	return data[at : at+32], nil
+}
+
+// Offset or length word, which must fit in the data it points into.
+func readLength(data []byte, at int) (int, error) {
+	word, err := readWord(data, at)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, fmt.Errorf("length at %d out of range", at)
+		}
+	}
+	n := binary.BigEndian.Uint64(word[24:])
+	if n > uint64(len(data)) {
+		return 0, fmt.Errorf("length %d at %d out of range", n, at)
+	}
+	return int(n), nil
+}
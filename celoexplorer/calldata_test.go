@@ -0,0 +1,220 @@
+package celoexplorer
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ABI encoded data from 32 byte words. Integers become one word, hex strings are padded on the right to whole words.
+func encode(words ...interface{}) []byte {
+	var data []byte
+	for _, w := range words {
+		word := make([]byte, 32)
+		switch w := w.(type) {
+		case int:
+			new(big.Int).SetInt64(int64(w)).FillBytes(word)
+		case string:
+			b, err := hex.DecodeString(w)
+			if err != nil {
+				panic(err)
+			}
+			word = make([]byte, (len(b)+31)/32*32)
+			copy(word, b)
+		}
+		data = append(data, word...)
+	}
+	return data
+}
+
+// Compact form of decoded values, telling their Go types apart.
+func showValue(v interface{}) string {
+	switch v := v.(type) {
+	case *big.Int:
+		return v.String()
+	case Address:
+		return strings.ToLower(v.String())
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		s := make([]string, len(v))
+		for i, e := range v {
+			s[i] = showValue(e)
+		}
+		return "[" + strings.Join(s, " ") + "]"
+	case []DecodedArg:
+		return "(" + showArgs(v) + ")"
+	}
+	return "?"
+}
+
+func showArgs(args []DecodedArg) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		s[i] = showValue(a.Value)
+	}
+	return strings.Join(s, ",")
+}
+
+const ones = "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+
+func TestDecodeArguments(t *testing.T) {
+	tests := []struct {
+		name  string
+		types string
+		data  []byte
+		want  string
+	}{
+		{"static", "uint256,address,bool", encode(7, "000000000000000000000000471ece3750da237f93b8e339c536989b8978a438", 1), `7,0x471ece3750da237f93b8e339c536989b8978a438,true`},
+		{"negative int256", "int256", encode(ones), `-1`},
+		{"negative int8", "int8", encode(ones[:62] + "fe"), `-2`},
+		{"positive int", "int", encode(5), `5`},
+		{"int256 min", "int256", encode("80" + strings.Repeat("00", 31)), `-57896044618658097711785492504343953926634992332820282019728792003956564819968`},
+		{"bytes4", "bytes4", encode("a9059cbb"), `0xa9059cbb`},
+		{"string", "string", encode(0x20, 5, "68656c6c6f"), `"hello"`},
+		{"empty string", "string", encode(0x20, 0), `""`},
+		{"bytes after static", "bytes,uint256", encode(0x40, 7, 3, "010203"), `0x010203,7`},
+		{"static array", "uint256[2],bool", encode(1, 2, 1), `[1 2],true`},
+		{"empty static array", "uint256[0],bool", encode(1), `[],true`},
+		{"slice", "uint256[]", encode(0x20, 2, 1, 2), `[1 2]`},
+		{"empty slice", "uint256[]", encode(0x20, 0), `[]`},
+		{"nested slice", "uint256[][]", encode(0x20, 2, 0x40, 0x80, 1, 5, 2, 6, 7), `[[5] [6 7]]`},
+		{"array of strings", "string[2]", encode(0x20, 0x40, 0x80, 1, "61", 2, "6263"), `["a" "bc"]`},
+		{"static tuple", "(uint256,bool),uint256", encode(1, 1, 2), `(1,true),2`},
+		{"dynamic tuple", "(uint256,string)", encode(0x20, 7, 0x40, 2, "6869"), `(7,"hi")`},
+		{"tuple slice", "(uint256,bool)[]", encode(0x20, 2, 1, 1, 2, 0), `[(1,true) (2,false)]`},
+		{"nested tuple", "((uint256,uint256[]),bool)", encode(0x20, 0x40, 1, 3, 0x40, 1, 9), `((3,[9]),true)`},
+		{"no arguments", "", nil, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := parseTypeList(tt.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := DecodeArguments(args, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := showArgs(decoded); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeArgumentsNames(t *testing.T) {
+	args := []ABIArgument{
+		{Name: "calls", Type: "tuple[]", Components: []ABIArgument{{Name: "target", Type: "address"}, {Name: "data", Type: "bytes"}}},
+		{Name: "n", Type: "uint"},
+	}
+	data := encode(0x40, 3, 1, 0x20, 0x471ece, 0x40, 1, "ff")
+
+	decoded, err := DecodeArguments(args, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded[0].Name != "calls" || decoded[0].Type != "(address,bytes)[]" || decoded[1].Name != "n" || decoded[1].Type != "uint256" {
+		t.Errorf("got arguments %+v", decoded)
+	}
+	call := decoded[0].Value.([]interface{})[0].([]DecodedArg)
+	if call[0].Name != "target" || call[1].Name != "data" || call[1].Type != "bytes" {
+		t.Errorf("got tuple fields %+v", call)
+	}
+	if got := showValue(call[1].Value); got != "0xff" {
+		t.Errorf("got data %s, want 0xff", got)
+	}
+}
+
+// Truncated and malicious data must fail without panicking or allocating what the data claims.
+func TestDecodeArgumentsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		types string
+		data  []byte
+	}{
+		{"no data", "uint256", nil},
+		{"short word", "uint256", encode(1)[:31]},
+		{"missing second word", "uint256,uint256", encode(1)},
+		{"bool out of range", "bool", encode(2)},
+		{"offset past the end", "string", encode(0x1000)},
+		{"offset into the last word", "string", encode(0x20)},
+		{"offset over 64 bits", "bytes", encode("01" + strings.Repeat("00", 31))},
+		{"offset of max uint64", "bytes", encode("000000000000000000000000000000000000000000000000ffffffffffffffff")},
+		{"string length past the end", "string", encode(0x20, 0x100, "6869")},
+		{"huge string length", "string", encode(0x20, ones)},
+		{"slice length past the end", "uint256[]", encode(0x20, 1000, 1, 2)},
+		{"huge slice length", "uint256[]", encode(0x20, "0000000000000000000000000000000000000000000000000fffffffffffffff")},
+		{"static array past the end", "uint256[3]", encode(1, 2)},
+		{"huge static array", "uint256[100000000]", encode(1, 2)},
+		{"nested offset past the end", "uint256[][]", encode(0x20, 1, 0xffff)},
+		{"nested length past the end", "uint256[][]", encode(0x20, 1, 0x20, 50)},
+		{"tuple past the end", "(uint256,uint256)", encode(1)},
+		{"tuple string offset past the end", "(uint256,string)", encode(0x20, 7, 0x500)},
+		{"unsupported type", "fixed128x18", encode(1)},
+		{"invalid int size", "uint7", encode(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := parseTypeList(tt.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := DecodeArguments(args, tt.data)
+			if err == nil {
+				t.Errorf("decoded %s, want an error", showArgs(decoded))
+			}
+		})
+	}
+}
+
+func TestDecodeValueOutOfRange(t *testing.T) {
+	typ := &abiType{kind: abiUint, size: 256}
+	data := encode(1)
+
+	for _, at := range []int{-1, -32, 1, 33, 1 << 40} {
+		if v, err := decodeValue(typ, data, at); err == nil {
+			t.Errorf("decoded %v at %d, want an error", v, at)
+		}
+	}
+	if v, err := decodeValue(typ, data, 0); err != nil || showValue(v) != "1" {
+		t.Errorf("got %v, %v, want 1", v, err)
+	}
+}
+
+func TestDecodeTupleHeads(t *testing.T) {
+	args, err := parseTypeList("uint256[2][2],(bool,uint256),string,uint256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types, err := newABITypes(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// static values are inline, 4 words for the nested array and 2 for the tuple, the string sits behind its offset
+	data := encode(1, 2, 3, 4, 1, 5, 0x100, 6, 1, "78")
+	values, err := decodeTuple(types, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, v := range values {
+		got = append(got, showValue(v))
+	}
+	if want := `[[1 2] [3 4]] (true,5) "x" 6`; strings.Join(got, " ") != want {
+		t.Errorf("got %s, want %s", strings.Join(got, " "), want)
+	}
+}
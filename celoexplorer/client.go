@@ -4,53 +4,61 @@
 package celoexplorer
 
 import (
+	"context"
 	"encoding/hex"
+	"fmt"
 	"math/big"
-	"net/http"
-	"strconv"
 	"strings"
 	"time"
 )
 
+// Explorer api urls. See also the network profiles Mainnet, Alfajores and Baklava.
 const (
 	BaseUrl        string = "https://explorer.celo.org/api"
 	TestnetBaseUrl string = "https://alfajores-blockscout.celo-testnet.org/api"
+)
 
+var (
 	// Celo contract address
-	CeloGold string = "471ece3750da237f93b8e339c536989b8978a438"
+	CeloGold = MustParseAddress("0x471EcE3750Da237f93B8E339c536989b8978a438")
 	// CeloUSD contract address
-	CeloUSD  string = "765de816845861e75a25fca122bb6898b8b1282a"
+	CeloUSD = MustParseAddress("0x765DE816845861e75A25fCA122bb6898B8B1282a")
 	// CeloEUR contract address
-	CeloEUR  string = "d8763cba276a3738e6de85b4b3bf5fded6d6ca73"
-	
+	CeloEUR = MustParseAddress("0xD8763CBa276a3738E6DE85b4b3bF5FDed6D6cA73")
+
 	// Alfajores Testnet Celo contract address
-	TestnetCeloGold string = "F194afDf50B03e69Bd7D057c1Aa9e10c9954E4C9"
+	TestnetCeloGold = MustParseAddress("0xF194afDf50B03e69Bd7D057c1Aa9e10c9954E4C9")
 	// Alfajores Testnet CeloUSD contract address
-	TestnetCeloUSD string = "874069Fa1Eb16D44d622F2e0Ca25eeA172369bC1"
+	TestnetCeloUSD = MustParseAddress("0x874069Fa1Eb16D44d622F2e0Ca25eeA172369bC1")
 	// Alfajores Testnet CeloEUR contract address
-	TestnetCeloEUR string = "10c892A6EC43a53E45D0B916B4b7D383B1b78C0F"
+	TestnetCeloEUR = MustParseAddress("0x10c892A6EC43a53E45D0B916B4b7D383B1b78C0F")
 )
+
 type Client struct {
-	req  *RequestClient
+	req     *RequestClient
+	network *Network
+	lenient bool
+	abis    abiCache
 }
 
-func New(url string) *Client {
-	tr := &http.Transport{
-		MaxIdleConns:    100,
-		IdleConnTimeout: 30 * time.Second,
+// Create a client for the explorer api at url, such as BaseUrl. Without options it uses an http client with pooled connections and no timeout.
+// Fails if url is not an absolute http(s) url ending in /api.
+func New(url string, opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
-	httpClient := &http.Client{Transport: tr}
-	reqClient := NewRequestClientWithHttp(url, httpClient)
-	return &Client{
-		req: reqClient,
+
+	req, err := o.buildRequestClient(url)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// errors are ignored
-func toBigInt(value string, base int) *big.Int {
-	n := new(big.Int)
-	n, _ = n.SetString(value, base)
-	return n
+	return &Client{
+		req:     req,
+		network: o.network,
+		lenient: o.lenient,
+	}, nil
 }
 
 func trim0x(s string) string {
@@ -67,101 +75,144 @@ func hexToByte(s string) []byte {
 
 // Mimics Ethereum JSON RPC's eth_getBalance.
 // Returns the wei balance (1 Celo = 10^18 wei) for an address as of the provided block (defaults to latest).
-func (c *Client) EthGetBalance(address string, block *big.Int) (*big.Int, error) {
-	bal, err := c.req.EthGetBalance(address, block)
+func (c *Client) EthGetBalance(address Address, block *big.Int) (*big.Int, error) {
+	return c.EthGetBalanceCtx(context.Background(), address, block)
+}
+
+// EthGetBalanceCtx is EthGetBalance with a context.
+func (c *Client) EthGetBalanceCtx(ctx context.Context, address Address, block *big.Int) (*big.Int, error) {
+	bal, err := c.req.EthGetBalanceCtx(ctx, address, block)
 	if err != nil {
 		return nil, err
 	}
 
-	b := toBigInt(trim0x(bal), 16)
-	return b, nil
+	d := c.decoder("EthGetBalance")
+	b := d.bigInt("result", bal, 16)
+	return b, d.err
+}
+
+// Mimics Ethereum JSON RPC's eth_blockNumber. Returns the lastest block number.
+func (c *Client) EthBlockNumber() (*big.Int, error) {
+	return c.EthBlockNumberCtx(context.Background())
+}
+
+// EthBlockNumberCtx is EthBlockNumber with a context.
+func (c *Client) EthBlockNumberCtx(ctx context.Context) (*big.Int, error) {
+	number, err := c.req.EthBlockNumberCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("EthBlockNumber")
+	n := d.bigInt("result", number, 16)
+	return n, d.err
 }
 
 // Get balance for address.
-func (c *Client) Balance(address string) (*big.Int, error) {
-	bal, err := c.req.Balance(address)
+func (c *Client) Balance(address Address) (*big.Int, error) {
+	return c.BalanceCtx(context.Background(), address)
+}
+
+// BalanceCtx is Balance with a context.
+func (c *Client) BalanceCtx(ctx context.Context, address Address) (*big.Int, error) {
+	bal, err := c.req.BalanceCtx(ctx, address)
 	if err != nil {
 		return nil, err
 	}
-	
-	b := toBigInt(string(bal), 10)
-	return b, nil
+
+	d := c.decoder("Balance")
+	b := d.bigInt("result", string(bal), 10)
+	return b, d.err
 }
 
 type FetchedBalance struct {
-	Address string
+	Address Address
 	Balance *big.Int
 	Stale   bool
 }
 
 // Get balance for multiple addresses.
 // If the balance hasn't been updated in a long time, we will double check with the node to fetch the absolute latest balance. This will not be reflected in the current request, but once it is updated, subsequent requests will show the updated balance. You can know that this is taking place via the `stale` attribute, which is set to `true` if a new balance is being fetched.
-func (c *Client) BalanceMulti(address []string) ([]FetchedBalance, error) {
-	bal, err := c.req.BalanceMulti(address)
+func (c *Client) BalanceMulti(address []Address) ([]FetchedBalance, error) {
+	return c.BalanceMultiCtx(context.Background(), address)
+}
+
+// BalanceMultiCtx is BalanceMulti with a context.
+func (c *Client) BalanceMultiCtx(ctx context.Context, address []Address) ([]FetchedBalance, error) {
+	bal, err := c.req.BalanceMultiCtx(ctx, address)
 	if err != nil {
 		return nil, err
 	}
 
+	d := c.decoder("BalanceMulti")
 	result := make([]FetchedBalance, len(bal))
 	for i, v := range bal {
-		result[i].Address = trim0x(v.Account)
-		result[i].Balance = toBigInt(v.Balance, 10)
+		d.at(i)
+		result[i].Address = d.address("account", v.Account)
+		result[i].Balance = d.bigInt("balance", v.Balance, 10)
 		result[i].Stale = v.Stale
 	}
-	return result, nil
+	return result, d.err
 }
 
 type Transaction struct {
-	BlockHash           string
+	BlockHash           Hash
 	BlockNumber         *big.Int
 	Confirmations       *big.Int
-	Contractaddress     string
+	Contractaddress     Address
 	CumulativeGasUsed   int
-	Feecurrency         string
-	From                string
+	Feecurrency         Address
+	From                Address
 	Gas                 int
 	GasPrice            *big.Int
 	GasUsed             int
 	GatewayFee          int
-	GatewayFeeRecipient string
-	Hash                string
+	GatewayFeeRecipient Address
+	Hash                Hash
 	Input               []byte
 	IsError             bool
 	Nonce               int
 	Timestamp           time.Time
-	To                  string
+	To                  Address
 	TransactionIndex    int
 	TxReceiptStatus     bool
 	Value               *big.Int
 }
 
 // Get transactions sent by an address. Up to a maximum of 10,000 transactions.
-func (c *Client) TxList(address string, sort *sortDirectionType, block *BlockRange, page *PageRange, filter *filterDirectionType, timeRange *TimeRange) ([]Transaction, error) {
-	txList, err := c.req.TxList(address, sort, block, page, filter, timeRange)
+func (c *Client) TxList(address Address, sort *sortDirectionType, block *BlockRange, page *PageRange, filter *filterDirectionType, timeRange *TimeRange) ([]Transaction, error) {
+	return c.TxListCtx(context.Background(), address, sort, block, page, filter, timeRange)
+}
+
+// TxListCtx is TxList with a context.
+func (c *Client) TxListCtx(ctx context.Context, address Address, sort *sortDirectionType, block *BlockRange, page *PageRange, filter *filterDirectionType, timeRange *TimeRange) ([]Transaction, error) {
+	txList, err := c.req.TxListCtx(ctx, address, sort, block, page, filter, timeRange)
 	if err != nil {
 		return nil, err
 	}
 
+	d := c.decoder("TxList")
 	transactions := make([]Transaction, len(txList))
 	for i, v := range txList {
-		transactions[i].BlockHash = trim0x(v.Blockhash)
-		transactions[i].BlockNumber = toBigInt(v.Blocknumber, 10)
-		transactions[i].Confirmations = toBigInt(v.Confirmations, 10)
-		transactions[i].Contractaddress = trim0x(v.Contractaddress)
-		transactions[i].CumulativeGasUsed, _ = strconv.Atoi(v.Cumulativegasused)
+		d.at(i)
+		transactions[i].BlockHash = d.hash("blockHash", v.Blockhash)
+		transactions[i].BlockNumber = d.bigInt("blockNumber", v.Blocknumber, 10)
+		transactions[i].Confirmations = d.bigInt("confirmations", v.Confirmations, 10)
+		transactions[i].Contractaddress = d.optional().address("contractAddress", v.Contractaddress)
+		transactions[i].CumulativeGasUsed = d.int("cumulativeGasUsed", v.Cumulativegasused, 10)
 
-		transactions[i].Feecurrency = v.Feecurrency
-		transactions[i].From = trim0x(v.From)
-		transactions[i].Gas, _ = strconv.Atoi(v.Gas)
+		transactions[i].Feecurrency = d.optional().address("feeCurrency", v.Feecurrency)
+		transactions[i].From = d.address("from", v.From)
+		transactions[i].Gas = d.int("gas", v.Gas, 10)
 
-		transactions[i].GasPrice = toBigInt(v.Gasprice, 10)
-		transactions[i].GasUsed, _ = strconv.Atoi(v.Gasused)
+		transactions[i].GasPrice = d.bigInt("gasPrice", v.Gasprice, 10)
+		transactions[i].GasUsed = d.int("gasUsed", v.Gasused, 10)
 
-		transactions[i].GatewayFee, _ = strconv.Atoi(v.Gatewayfee)
+		transactions[i].GatewayFee = d.optional().int("gatewayFee", v.Gatewayfee, 10)
 
-		transactions[i].GatewayFeeRecipient = trim0x(v.Gatewayfeerecipient)
-		transactions[i].Hash = trim0x(v.Hash)
-		transactions[i].Input = hexToByte(trim0x(v.Input))
+		transactions[i].GatewayFeeRecipient = d.optional().address("gatewayFeeRecipient", v.Gatewayfeerecipient)
+		transactions[i].Hash = d.hash("hash", v.Hash)
+		transactions[i].Input = d.bytes("input", v.Input)
 
 		if v.Iserror == "0" {
 			transactions[i].IsError = false
@@ -169,13 +220,12 @@ func (c *Client) TxList(address string, sort *sortDirectionType, block *BlockRan
 			transactions[i].IsError = true
 		}
 
-		transactions[i].Nonce, _ = strconv.Atoi(v.Nonce)
+		transactions[i].Nonce = d.int("nonce", v.Nonce, 10)
 
-		iTime, _ := strconv.ParseInt(v.Timestamp, 10, 64)
-		transactions[i].Timestamp = time.Unix(iTime, 0)
+		transactions[i].Timestamp = d.unix("timeStamp", v.Timestamp, 10)
 
-		transactions[i].To = trim0x(v.To)
-		transactions[i].TransactionIndex, _ = strconv.Atoi(v.Transactionindex)
+		transactions[i].To = d.optional().address("to", v.To)
+		transactions[i].TransactionIndex = d.int("transactionIndex", v.Transactionindex, 10)
 
 		if v.TxreceiptStatus == "1" {
 			transactions[i].TxReceiptStatus = true
@@ -183,25 +233,25 @@ func (c *Client) TxList(address string, sort *sortDirectionType, block *BlockRan
 			transactions[i].TxReceiptStatus = false
 		}
 
-		transactions[i].Value = toBigInt(v.Value, 10)
+		transactions[i].Value = d.bigInt("value", v.Value, 10)
 	}
 
-	return transactions, nil
+	return transactions, d.err
 }
 
 type TokenTransfer struct {
 	Value *big.Int
-	BlockHash string
+	BlockHash Hash
 	BlockNumber *big.Int
 	Confirmations *big.Int
-	ContractAddress string
+	ContractAddress Address
 	CumulativeGasUsed int
-	From string
-	To string
+	From Address
+	To Address
 	Gas int
 	Gasprice *big.Int
 	Gasused int
-	Hash string
+	Hash Hash
 	Input []byte
 	LogIndex int
 	Nonce int
@@ -213,59 +263,72 @@ type TokenTransfer struct {
 }
 
 // Get token transfer events to and from an address.
-func (c *Client) TokenTx(address string, contractAddress *string, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TokenTransfer, error) {
-	tokensList, err := c.req.TokenTx(address, contractAddress, sort, block, page)
+func (c *Client) TokenTx(address Address, contractAddress *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TokenTransfer, error) {
+	return c.TokenTxCtx(context.Background(), address, contractAddress, sort, block, page)
+}
+
+// TokenTxCtx is TokenTx with a context.
+func (c *Client) TokenTxCtx(ctx context.Context, address Address, contractAddress *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TokenTransfer, error) {
+	tokensList, err := c.req.TokenTxCtx(ctx, address, contractAddress, sort, block, page)
 	if err != nil {
 		return nil, err
 	}
 
+	d := c.decoder("TokenTx")
 	tokens := make([]TokenTransfer, len(tokensList))
 	for i, v := range tokensList {
-		tokens[i].Value = toBigInt(v.Value, 10)
-		tokens[i].BlockHash = trim0x(v.Blockhash)
-		tokens[i].BlockNumber = toBigInt(v.Blocknumber, 10)
-		tokens[i].Confirmations = toBigInt(v.Confirmations, 10)
-		tokens[i].ContractAddress = trim0x(v.Contractaddress)
-		tokens[i].CumulativeGasUsed, _ = strconv.Atoi(v.Cumulativegasused)
-
-		tokens[i].From = trim0x(v.From)
-		tokens[i].To = trim0x(v.To)
-
-		tokens[i].Gas, _ = strconv.Atoi(v.Gas)
-		tokens[i].Gasprice = toBigInt(v.Gasprice, 10)
-		tokens[i].Gasused, _ = strconv.Atoi(v.Gasused)
+		d.at(i)
+		tokens[i].Value = d.bigInt("value", v.Value, 10)
+		tokens[i].BlockHash = d.hash("blockHash", v.Blockhash)
+		tokens[i].BlockNumber = d.bigInt("blockNumber", v.Blocknumber, 10)
+		tokens[i].Confirmations = d.bigInt("confirmations", v.Confirmations, 10)
+		tokens[i].ContractAddress = d.address("contractAddress", v.Contractaddress)
+		tokens[i].CumulativeGasUsed = d.int("cumulativeGasUsed", v.Cumulativegasused, 10)
+
+		tokens[i].From = d.address("from", v.From)
+		tokens[i].To = d.address("to", v.To)
+
+		tokens[i].Gas = d.int("gas", v.Gas, 10)
+		tokens[i].Gasprice = d.bigInt("gasPrice", v.Gasprice, 10)
+		tokens[i].Gasused = d.int("gasUsed", v.Gasused, 10)
 	
-		tokens[i].Hash = trim0x(v.Hash)
-		tokens[i].Input = hexToByte(trim0x(v.Input))
-		tokens[i].LogIndex, _ = strconv.Atoi(v.Logindex) 
+		tokens[i].Hash = d.hash("hash", v.Hash)
+		tokens[i].Input = d.bytes("input", v.Input)
+		tokens[i].LogIndex = d.int("logIndex", v.Logindex, 10) 
 
-		tokens[i].Nonce, _ = strconv.Atoi(v.Nonce) 
+		tokens[i].Nonce = d.int("nonce", v.Nonce, 10) 
 
-		iTime, _ := strconv.ParseInt(v.Timestamp, 10, 64)
-		tokens[i].Timestamp = time.Unix(iTime, 0)
+		tokens[i].Timestamp = d.unix("timeStamp", v.Timestamp, 10)
 
-		tokens[i].TokenDecimal, _ = strconv.Atoi(v.Tokendecimal) 
+		tokens[i].TokenDecimal = d.optional().int("tokenDecimal", v.Tokendecimal, 10) 
 		tokens[i].TokenName = v.Tokenname
 		tokens[i].TokenSymbol = v.Tokensymbol
 
-		tokens[i].TransactionIndex, _ = strconv.Atoi(v.Transactionindex) 
+		tokens[i].TransactionIndex = d.int("transactionIndex", v.Transactionindex, 10) 
 	}
-	return tokens, nil
+	return tokens, d.err
 }
 
 // Get token account balance for token contract address.
-func (c *Client) TokenBalance(contractAddress, address string) (*big.Int, error) {
-	bal, err := c.req.TokenBalance(contractAddress, address)
+func (c *Client) TokenBalance(contractAddress, address Address) (*big.Int, error) {
+	return c.TokenBalanceCtx(context.Background(), contractAddress, address)
+}
+
+// TokenBalanceCtx is TokenBalance with a context.
+func (c *Client) TokenBalanceCtx(ctx context.Context, contractAddress, address Address) (*big.Int, error) {
+	bal, err := c.req.TokenBalanceCtx(ctx, contractAddress, address)
 	if err != nil {
 		return nil, err
 	}
 
-	return toBigInt(string(bal), 10), nil
+	d := c.decoder("TokenBalance")
+	b := d.bigInt("result", string(bal), 10)
+	return b, d.err
 }
 
 type Token struct {
 	Balance *big.Int
-	ContractAddress string
+	ContractAddress Address
 	Decimals int
 	Name string
 	Symbol string
@@ -273,86 +336,92 @@ type Token struct {
 }
 
 // Get list of tokens owned by address.
-func (c *Client) TokenList(address string) ([]Token, error) {
-	tokenList, err := c.req.TokenList(address)
+func (c *Client) TokenList(address Address) ([]Token, error) {
+	return c.TokenListCtx(context.Background(), address)
+}
+
+// TokenListCtx is TokenList with a context.
+func (c *Client) TokenListCtx(ctx context.Context, address Address) ([]Token, error) {
+	tokenList, err := c.req.TokenListCtx(ctx, address)
 	if err != nil {
 		return nil, err
 	}
 
+	d := c.decoder("TokenList")
 	tokens := make([]Token, len(tokenList))
 	for i, v := range tokenList {
-		tokens[i].Balance = toBigInt(v.Balance, 10)
-		tokens[i].ContractAddress = trim0x(v.Contractaddress)
-		tokens[i].Decimals, _ = strconv.Atoi(v.Decimals)
+		d.at(i)
+		tokens[i].Balance = d.bigInt("balance", v.Balance, 10)
+		tokens[i].ContractAddress = d.address("contractAddress", v.Contractaddress)
+		tokens[i].Decimals = d.optional().int("decimals", v.Decimals, 10)
 		tokens[i].Name = v.Name
 		tokens[i].Symbol = v.Symbol
 		tokens[i].Type = v.Type
 	}
-	return tokens, nil
+	return tokens, d.err
 }
 
 type EventLog struct {
-	Address string
+	Address Address
 	BlockNumber *big.Int
 	Data string
 	// address of fee currency
-	FeeCurrency string
+	FeeCurrency Address
 	GasPrice *big.Int
 	GasUsed int
 	GatewayFee *big.Int
-	GatewayfeeRecipient string
+	GatewayfeeRecipient Address
 	LogIndex int
 	Timestamp time.Time
-	Topics []string
-	TransactionHash string
+	Topics []Hash
+	TransactionHash Hash
 	TransactionIndex int
 }
 
 // WARNING: This function may not work correctly since I am not sure whether the returned data is in hex or decimal form.
 // Get event logs for an address and/or topics. Up to a maximum of 1,000 event logs.
-func (c *Client) GetLogs(block BlockRangeAdv, contractAddress string, topics Topics) ([]EventLog, error) {
-	logList, err := c.req.GetLogs(block, contractAddress, topics)
+func (c *Client) GetLogs(block BlockRangeAdv, contractAddress Address, topics Topics) ([]EventLog, error) {
+	return c.GetLogsCtx(context.Background(), block, contractAddress, topics)
+}
+
+// GetLogsCtx is GetLogs with a context.
+func (c *Client) GetLogsCtx(ctx context.Context, block BlockRangeAdv, contractAddress Address, topics Topics) ([]EventLog, error) {
+	logList, err := c.req.GetLogsCtx(ctx, block, contractAddress, topics)
 	if err != nil {
 		return nil ,err
 	}
 
+	d := c.decoder("GetLogs")
 	logs := make([]EventLog, len(logList))
 	for i, v := range logList {
-		logs[i].Address = trim0x(v.Address)
-		logs[i].BlockNumber = toBigInt(trim0x(v.Blocknumber), 16)
+		d.at(i)
+		logs[i].Address = d.address("address", v.Address)
+		logs[i].BlockNumber = d.bigInt("blockNumber", v.Blocknumber, 16)
 		logs[i].Data = trim0x(v.Data)
-		logs[i].FeeCurrency = trim0x(v.Feecurrency)
-		logs[i].GasPrice = toBigInt(trim0x(v.Gasprice), 16)
+		logs[i].FeeCurrency = d.optional().address("feeCurrency", v.Feecurrency)
+		logs[i].GasPrice = d.bigInt("gasPrice", v.Gasprice, 16)
 
-		gasUsed, _ := strconv.ParseInt(trim0x(v.Gasused), 16, 64)
-		logs[i].GasUsed = int(gasUsed)
+		logs[i].GasUsed = d.int("gasUsed", v.Gasused, 16)
 
-		logs[i].GatewayFee = toBigInt(v.Gatewayfee, 10)
-		logs[i].GatewayfeeRecipient = trim0x(v.Gatewayfeerecipient)
-		lIndex, _  := strconv.ParseInt(trim0x(v.Logindex), 16, 64)
-		logs[i].LogIndex = int(lIndex)
+		logs[i].GatewayFee = d.optional().bigInt("gatewayFee", v.Gatewayfee, 10)
+		logs[i].GatewayfeeRecipient = d.optional().address("gatewayFeeRecipient", v.Gatewayfeerecipient)
+		logs[i].LogIndex = d.int("logIndex", v.Logindex, 16)
 
-		iTime, _ := strconv.ParseInt(trim0x(v.Timestamp), 16, 64)
-		logs[i].Timestamp = time.Unix(iTime, 0)
+		logs[i].Timestamp = d.unix("timeStamp", v.Timestamp, 16)
 
-		array := make([]string, len(v.Topics))
-		for i, v := range v.Topics {
-			array[i] = trim0x(v)
-		}
-		logs[i].Topics = array
+		logs[i].Topics = d.hashes("topics", v.Topics)
 
-		logs[i].TransactionHash = trim0x(v.Transactionhash)
+		logs[i].TransactionHash = d.hash("transactionHash", v.Transactionhash)
 
-		tIndex, _ := strconv.ParseInt(trim0x(v.Transactionindex), 16, 64)
-		logs[i].TransactionIndex = int(tIndex)
+		logs[i].TransactionIndex = d.int("transactionIndex", v.Transactionindex, 16)
 	}
 
-	return logs, nil
+	return logs, d.err
 }
 
 type TokenInfo struct {
 	Catalogued bool
-	ContractAddress string
+	ContractAddress Address
 	Decimals int
 	Name string
 	Symbol string
@@ -361,104 +430,112 @@ type TokenInfo struct {
 }
 
 // Get ERC-20 or ERC-721 token by contract address.
-func (c *Client) GetToken(contractAddress string) (TokenInfo, error) {
-	info, err := c.req.GetToken(contractAddress)
+func (c *Client) GetToken(contractAddress Address) (TokenInfo, error) {
+	return c.GetTokenCtx(context.Background(), contractAddress)
+}
+
+// GetTokenCtx is GetToken with a context.
+func (c *Client) GetTokenCtx(ctx context.Context, contractAddress Address) (TokenInfo, error) {
+	info, err := c.req.GetTokenCtx(ctx, contractAddress)
 	if err != nil {
 		return TokenInfo{}, err
 	}
 
-	deci, _ := strconv.Atoi(info.Decimals)
+	d := c.decoder("GetToken")
 	return TokenInfo{
 		Catalogued:      info.Cataloged,
-		ContractAddress: trim0x(info.Contractaddress),
-		Decimals:        deci,
+		ContractAddress: d.address("contractAddress", info.Contractaddress),
+		Decimals:        d.optional().int("decimals", info.Decimals, 10),
 		Name:            info.Name,
 		Symbol:          info.Symbol,
-		TotalSupply:     toBigInt(info.Totalsupply, 10),
+		TotalSupply:     d.optional().bigInt("totalSupply", info.Totalsupply, 10),
 		Type:            info.Type,
-	}, nil
+	}, d.err
 }
 
 type TxLog struct {
-	Address string
+	Address Address
 	Data 	[]byte
 	Index	int
-	Topics	[]string
+	Topics	[]Hash
 }
 
 type TransactionWithLogs struct {
 	BlockNumber         *big.Int
 	Confirmations       *big.Int
-	Feecurrency         string
-	From                string
+	Feecurrency         Address
+	From                Address
 	GasLimit            *big.Int
 	GasPrice            *big.Int
 	GasUsed             int
 	GatewayFee          *big.Int
-	GatewayFeeRecipient string
-	Hash                string
+	GatewayFeeRecipient Address
+	Hash                Hash
 	Input               []byte
 	Logs				[]TxLog
 	RevertReason		string
 	Success				bool
 	Timestamp           time.Time
-	To                  string
+	To                  Address
 	Value               *big.Int
 }
 
 // Get transaction info.
-func (c *Client) GetTxInfo(txHash string) (TransactionWithLogs, error) {
-	txInfo, err := c.req.GetTxInfo(txHash, nil)
+func (c *Client) GetTxInfo(txHash Hash) (TransactionWithLogs, error) {
+	return c.GetTxInfoCtx(context.Background(), txHash)
+}
+
+// GetTxInfoCtx is GetTxInfo with a context.
+func (c *Client) GetTxInfoCtx(ctx context.Context, txHash Hash) (TransactionWithLogs, error) {
+	txInfo, err := c.req.GetTxInfoCtx(ctx, txHash, nil)
 	if err != nil {
 		return TransactionWithLogs{}, err
 	}
 
-	gasUsed, _ := strconv.Atoi(txInfo.Gasused)
-
-	iTime, _ := strconv.ParseInt(trim0x(txInfo.Timestamp), 10, 64)
-	timestamp := time.Unix(iTime, 0)
-
+	d := c.decoder("GetTxInfo")
+	gasUsed := d.int("gasUsed", txInfo.Gasused, 10)
+	timestamp := d.unix("timeStamp", txInfo.Timestamp, 10)
 
 	logs := make([]TxLog, len(txInfo.Logs))
 	for i, v := range txInfo.Logs {
-		logs[i].Address = trim0x(v.Address)
-		logs[i].Data = []byte(trim0x(v.Data))
-
-		index, _ := strconv.Atoi(v.Index)
-		logs[i].Index = index
+		field := fmt.Sprintf("logs[%d].", i)
+		logs[i].Address = d.address(field+"address", v.Address)
+		logs[i].Data = d.bytes(field+"data", v.Data)
+		logs[i].Index = d.int(field+"index", v.Index, 10)
 
-		array := make([]string, len(v.Topics))
-		for i, v := range v.Topics {
-			array[i] = trim0x(v)
-		}
-		logs[i].Topics = array
+		logs[i].Topics = d.hashes(field+"topics", v.Topics)
 	}
 
 
 	return TransactionWithLogs{
-		BlockNumber:         toBigInt(txInfo.Blocknumber, 10),
-		Confirmations:       toBigInt(txInfo.Confirmations, 10),
-		Feecurrency:         trim0x(txInfo.Feecurrency),
-		From:                trim0x(txInfo.From),
-		GasLimit:            toBigInt(txInfo.Gaslimit, 10),
-		GasPrice:            toBigInt(txInfo.Gasused, 10),
+		BlockNumber:         d.bigInt("blockNumber", txInfo.Blocknumber, 10),
+		Confirmations:       d.bigInt("confirmations", txInfo.Confirmations, 10),
+		Feecurrency:         d.optional().address("feeCurrency", txInfo.Feecurrency),
+		From:                d.address("from", txInfo.From),
+		GasLimit:            d.bigInt("gasLimit", txInfo.Gaslimit, 10),
+		GasPrice:            d.bigInt("gasPrice", txInfo.Gasprice, 10),
 		GasUsed:             gasUsed,
-		GatewayFee:          toBigInt(txInfo.Gatewayfee, 10),
-		GatewayFeeRecipient: trim0x(txInfo.Gatewayfeerecipient),
-		Hash:                trim0x(txInfo.Hash),
-		Input:               []byte(trim0x(txInfo.Input)),
+		GatewayFee:          d.optional().bigInt("gatewayFee", txInfo.Gatewayfee, 10),
+		GatewayFeeRecipient: d.optional().address("gatewayFeeRecipient", txInfo.Gatewayfeerecipient),
+		Hash:                d.hash("hash", txInfo.Hash),
+		Input:               d.bytes("input", txInfo.Input),
 		Logs:                logs,
 		RevertReason:        txInfo.Revertreason,
 		Success:             txInfo.Success,
 		Timestamp:           timestamp,
-		To:                  trim0x(txInfo.To),
-		Value:               toBigInt(txInfo.Value, 10),
-	}, nil
+		To:                  d.optional().address("to", txInfo.To),
+		Value:               d.bigInt("value", txInfo.Value, 10),
+	}, d.err
 }
 
 // Get transaction receipt status. 
-func (c *Client) GetTxReceiptStatus(txHash string) (bool, error) {
-	status, err := c.req.GetTxReceiptStatus(txHash)
+func (c *Client) GetTxReceiptStatus(txHash Hash) (bool, error) {
+	return c.GetTxReceiptStatusCtx(context.Background(), txHash)
+}
+
+// GetTxReceiptStatusCtx is GetTxReceiptStatus with a context.
+func (c *Client) GetTxReceiptStatusCtx(ctx context.Context, txHash Hash) (bool, error) {
+	status, err := c.req.GetTxReceiptStatusCtx(ctx, txHash)
 	if err != nil {
 		return false, err
 	}
@@ -470,8 +547,13 @@ func (c *Client) GetTxReceiptStatus(txHash string) (bool, error) {
 }
 
 // Get error status and error message. 
-func (c *Client) GetStatus(txHash string) (bool, string, error) {
-	status, err := c.req.GetStatus(txHash)
+func (c *Client) GetStatus(txHash Hash) (bool, string, error) {
+	return c.GetStatusCtx(context.Background(), txHash)
+}
+
+// GetStatusCtx is GetStatus with a context.
+func (c *Client) GetStatusCtx(ctx context.Context, txHash Hash) (bool, string, error) {
+	status, err := c.req.GetStatusCtx(ctx, txHash)
 	if err != nil {
 		return false, "", err
 	}
@@ -480,4 +562,50 @@ func (c *Client) GetStatus(txHash string) (bool, string, error) {
 		return true, status.Errdescription, nil
 	}
 	return false, status.Errdescription, nil
-}
\ No newline at end of file
+}
+
+// PendingTransaction is a transaction that has been sent but not yet mined.
+type PendingTransaction struct {
+	ContractAddress   Address
+	CumulativeGasUsed uint64
+	From              Address
+	Gas               uint64
+	GasPrice          *big.Int
+	GasUsed           uint64
+	Hash              Hash
+	Input             []byte
+	Nonce             uint64
+	To                Address
+	Value             *big.Int
+}
+
+// Get pending transactions by address.
+func (c *Client) PendingTransactions(address Address, page *PageRange) ([]PendingTransaction, error) {
+	return c.PendingTransactionsCtx(context.Background(), address, page)
+}
+
+// PendingTransactionsCtx is PendingTransactions with a context.
+func (c *Client) PendingTransactionsCtx(ctx context.Context, address Address, page *PageRange) ([]PendingTransaction, error) {
+	pendingList, err := c.req.PendingTxListCtx(ctx, address, page)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("PendingTxList")
+	pending := make([]PendingTransaction, len(pendingList))
+	for i, v := range pendingList {
+		d.at(i)
+		pending[i].ContractAddress = d.optional().address("contractAddress", v.Contractaddress)
+		pending[i].CumulativeGasUsed = d.optional().uint64("cumulativeGasUsed", v.Cumulativegasused, 10)
+		pending[i].From = d.address("from", v.From)
+		pending[i].Gas = d.uint64("gas", v.Gas, 10)
+		pending[i].GasPrice = d.bigInt("gasPrice", v.Gasprice, 10)
+		pending[i].GasUsed = d.optional().uint64("gasUsed", v.Gasused, 10)
+		pending[i].Hash = d.hash("hash", v.Hash)
+		pending[i].Input = d.bytes("input", v.Input)
+		pending[i].Nonce = d.uint64("nonce", v.Nonce, 10)
+		pending[i].To = d.optional().address("to", v.To)
+		pending[i].Value = d.bigInt("value", v.Value, 10)
+	}
+	return pending, d.err
+}
@@ -0,0 +1,98 @@
+package celoexplorer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Contract struct {
+	Address Address `json:"address"`
+	Name    string  `json:"name"`
+	// Nil when the contract is not verified.
+	ABI              *ABI   `json:"abi"`
+	CompilerVersion  string `json:"compilerVersion"`
+	OptimizationUsed bool   `json:"optimizationUsed"`
+	SourceCode       string `json:"sourceCode,omitempty"`
+}
+
+// Get a list of contracts, sorted ascending by the time they were first seen by the explorer. The not_decompiled and unverified filters leave the results unsorted.
+func (c *Client) ListContracts(page *PageRange, filter *filterContractType, notVersion *string) ([]Contract, error) {
+	return c.ListContractsCtx(context.Background(), page, filter, notVersion)
+}
+
+// ListContractsCtx is ListContracts with a context.
+func (c *Client) ListContractsCtx(ctx context.Context, page *PageRange, filter *filterContractType, notVersion *string) ([]Contract, error) {
+	contractList, err := c.req.ListContractsCtx(ctx, page, filter, notVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("ListContracts")
+	contracts := make([]Contract, len(contractList))
+	for i, v := range contractList {
+		d.at(i)
+		contracts[i].Address = d.address("Address", v.Address)
+		contracts[i].Name = v.Contractname
+		contracts[i].ABI = d.abi("ABI", v.Abi)
+		contracts[i].CompilerVersion = v.Compilerversion
+		contracts[i].OptimizationUsed = d.optional().bool("OptimizationUsed", v.Optimizationused)
+		contracts[i].SourceCode = v.Sourcecode
+	}
+	return contracts, d.err
+}
+
+// Write every contract of it as one JSON object per line. Returns the number of contracts written.
+func ExportContractsJSONL(w io.Writer, it *ContractIterator) (int, error) {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	var n int
+	for it.Next() {
+		if err := enc.Encode(it.Value()); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		bw.Flush()
+		return n, err
+	}
+	return n, bw.Flush()
+}
+
+// Write every contract of it to dir, creating it if needed: the metadata and ABI to <address>.json and the source code, if any, to <address>.sol.
+// Returns the number of contracts written.
+func ExportContractsDir(dir string, it *ContractIterator) (int, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, err
+	}
+
+	var n int
+	for it.Next() {
+		contract := it.Value()
+		name := filepath.Join(dir, strings.ToLower(contract.Address.Hex()))
+
+		if contract.SourceCode != "" {
+			if err := ioutil.WriteFile(name+".sol", []byte(contract.SourceCode), 0644); err != nil {
+				return n, err
+			}
+		}
+
+		contract.SourceCode = ""
+		data, err := json.MarshalIndent(contract, "", "  ")
+		if err != nil {
+			return n, err
+		}
+		if err := ioutil.WriteFile(name+".json", append(data, '\n'), 0644); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, it.Err()
+}
@@ -0,0 +1,273 @@
+package celoexplorer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DecodeError reports a field of a response that Client could not convert. It matches ErrDecode with errors.Is.
+type DecodeError struct {
+	// Client method that received the response, e.g. "TxList".
+	Endpoint string
+	// Index of the record in a list response, -1 for single records.
+	Index int
+	// Name of the field in the response.
+	Field string
+	// Text that failed to convert.
+	Value string
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("celoexplorer: %s: cannot decode %s %q: %v", e.Endpoint, e.Field, e.Value, e.Err)
+	}
+	return fmt.Sprintf("celoexplorer: %s: record %d: cannot decode %s %q: %v", e.Endpoint, e.Index, e.Field, e.Value, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// Reported for a required field the explorer left empty.
+var errMissing = errors.New("missing value")
+
+// Converts the text fields of a response, remembering the first failure.
+// Empty fields fail, except for fields marked optional, which decode to the zero value.
+// A lenient decoder never fails and leaves fields it cannot convert at their zero value.
+type decoder struct {
+	endpoint   string
+	index      int
+	strict     bool
+	allowEmpty bool
+	err        error
+}
+
+// Decoder for a single record response of endpoint.
+func (c *Client) decoder(endpoint string) *decoder {
+	return &decoder{
+		endpoint: endpoint,
+		index:    -1,
+		strict:   !c.lenient,
+	}
+}
+
+// Move on to the record at index of a list response.
+func (d *decoder) at(index int) *decoder {
+	d.index = index
+	return d
+}
+
+// Let the next field be empty, for fields that only some records have, such as the contract address of a contract creation.
+func (d *decoder) optional() *decoder {
+	d.allowEmpty = true
+	return d
+}
+
+// Whether text is empty, which is a failure unless the field was marked optional.
+func (d *decoder) empty(field, value, text string) bool {
+	allowEmpty := d.allowEmpty
+	d.allowEmpty = false
+	if text != "" {
+		return false
+	}
+	if !allowEmpty {
+		d.fail(field, value, errMissing)
+	}
+	return true
+}
+
+func (d *decoder) fail(field, value string, err error) {
+	if d.strict && d.err == nil {
+		d.err = &DecodeError{
+			Endpoint: d.endpoint,
+			Index:    d.index,
+			Field:    field,
+			Value:    value,
+			Err:      err,
+		}
+	}
+}
+
+// Integer of any size, in base 10 or base 16. Hex text may start with 0x.
+func (d *decoder) bigInt(field, value string, base int) *big.Int {
+	text := value
+	if base == 16 {
+		text = trim0x(text)
+	}
+	if d.empty(field, value, text) {
+		return nil
+	}
+
+	n, ok := new(big.Int).SetString(text, base)
+	if !ok {
+		d.fail(field, value, fmt.Errorf("not a base %d integer", base))
+		return nil
+	}
+	return n
+}
+
+// Integer in base 10 or base 16. Hex text may start with 0x.
+func (d *decoder) int(field, value string, base int) int {
+	text := value
+	if base == 16 {
+		text = trim0x(text)
+	}
+	if d.empty(field, value, text) {
+		return 0
+	}
+
+	n, err := strconv.ParseInt(text, base, 0)
+	if err != nil {
+		d.fail(field, value, err)
+		return 0
+	}
+	return int(n)
+}
+
+// Non-negative integer in base 10 or base 16. Hex text may start with 0x.
+func (d *decoder) uint64(field, value string, base int) uint64 {
+	text := value
+	if base == 16 {
+		text = trim0x(text)
+	}
+	if d.empty(field, value, text) {
+		return 0
+	}
+
+	n, err := strconv.ParseUint(text, base, 64)
+	if err != nil {
+		d.fail(field, value, err)
+		return 0
+	}
+	return n
+}
+
+// Boolean given as true/false or 1/0.
+func (d *decoder) bool(field, value string) bool {
+	if d.empty(field, value, value) {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		d.fail(field, value, err)
+	}
+	return b
+}
+
+// Exact decimal number such as "0.000274".
+func (d *decoder) decimal(field, value string) *big.Rat {
+	if d.empty(field, value, value) {
+		return nil
+	}
+
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		d.fail(field, value, fmt.Errorf("not a decimal number"))
+		return nil
+	}
+	return r
+}
+
+// Unix timestamp in seconds, in base 10 or base 16.
+func (d *decoder) unix(field, value string, base int) time.Time {
+	text := value
+	if base == 16 {
+		text = trim0x(text)
+	}
+	if d.empty(field, value, text) {
+		return time.Unix(0, 0)
+	}
+
+	n, err := strconv.ParseInt(text, base, 64)
+	if err != nil {
+		d.fail(field, value, err)
+	}
+	return time.Unix(n, 0)
+}
+
+// Time given either as a Unix timestamp in seconds or in RFC 3339 format, as endpoints disagree on which one they use.
+func (d *decoder) time(field, value string) time.Time {
+	if d.empty(field, value, value) {
+		return time.Unix(0, 0)
+	}
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(n, 0)
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		d.fail(field, value, err)
+		return time.Unix(0, 0)
+	}
+	return t
+}
+
+// Hex encoded bytes, with or without 0x. Empty text is no bytes rather than a missing value.
+func (d *decoder) bytes(field, value string) []byte {
+	b, err := hex.DecodeString(trim0x(value))
+	if err != nil {
+		d.fail(field, value, err)
+		return hexToByte(trim0x(value))
+	}
+	return b
+}
+
+// Hex address, with or without 0x.
+func (d *decoder) address(field, value string) Address {
+	if d.empty(field, value, value) {
+		return Address{}
+	}
+
+	// the explorer does not always checksum correctly, so only the digits matter
+	a, err := ParseAddress(strings.ToLower(value))
+	if err != nil {
+		d.fail(field, value, err)
+	}
+	return a
+}
+
+// Hex hash, with or without 0x.
+func (d *decoder) hash(field, value string) Hash {
+	if d.empty(field, value, value) {
+		return Hash{}
+	}
+
+	h, err := ParseHash(value)
+	if err != nil {
+		d.fail(field, value, err)
+	}
+	return h
+}
+
+// JSON ABI. Unverified contracts come with a message instead of an ABI, which decodes to nil.
+func (d *decoder) abi(field, value string) *ABI {
+	if !strings.HasPrefix(strings.TrimSpace(value), "[") {
+		return nil
+	}
+
+	abi, err := ParseABI(value)
+	if err != nil {
+		d.fail(field, value, err)
+		return nil
+	}
+	return abi
+}
+
+func (d *decoder) hashes(field string, values []string) []Hash {
+	hashes := make([]Hash, len(values))
+	for i, v := range values {
+		hashes[i] = d.hash(fmt.Sprintf("%s[%d]", field, i), v)
+	}
+	return hashes
+}
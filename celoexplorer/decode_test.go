@@ -0,0 +1,96 @@
+package celoexplorer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// txlist record of a contract creation, which has no recipient, with blockNumber set to the given text.
+func txListBody(blockNumber string) string {
+	return fmt.Sprintf(`{"message":"OK","status":"1","result":[{"blockHash":"0x%064x","blockNumber":%q,"confirmations":"1","contractAddress":"0x765de816845861e75a25fca122bb6898b8b1282a","cumulativeGasUsed":"21000","feeCurrency":"","from":"0x471ece3750da237f93b8e339c536989b8978a438","gas":"21000","gasPrice":"1","gasUsed":"21000","gatewayFee":"","gatewayFeeRecipient":"","hash":"0x%064x","input":"0x","isError":"0","nonce":"1","timeStamp":"1600000000","to":"","transactionIndex":"0","txreceipt_status":"1","value":"0"}]}`, 1, blockNumber, 2)
+}
+
+func newBodyClient(t *testing.T, body string, opts ...Option) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL+"/api", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestDecodeEmptyFields(t *testing.T) {
+	address := MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438")
+
+	t.Run("optional", func(t *testing.T) {
+		txs, err := newBodyClient(t, txListBody("7")).TxList(address, nil, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !txs[0].To.IsZero() || !txs[0].Feecurrency.IsZero() || txs[0].GatewayFee != 0 {
+			t.Errorf("empty optional fields decoded to %+v", txs[0])
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		_, err := newBodyClient(t, txListBody("")).TxList(address, nil, nil, nil, nil, nil)
+		var derr *DecodeError
+		if !errors.As(err, &derr) {
+			t.Fatalf("got error %v, want a DecodeError", err)
+		}
+		if derr.Field != "blockNumber" || derr.Index != 0 || !errors.Is(err, errMissing) || !errors.Is(err, ErrDecode) {
+			t.Errorf("got %v", err)
+		}
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		txs, err := newBodyClient(t, txListBody(""), WithLenientDecoding()).TxList(address, nil, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if txs[0].BlockNumber != nil {
+			t.Errorf("got block number %s, want nil", txs[0].BlockNumber)
+		}
+	})
+
+	t.Run("latest block", func(t *testing.T) {
+		// the head block is needed to split a range, even when decoding is lenient
+		c := newBodyClient(t, `{"jsonrpc":"2.0","id":1,"result":""}`, WithLenientDecoding())
+		_, err := c.TxListAll(address, nil, nil)
+		if !errors.Is(err, ErrDecode) {
+			t.Errorf("got error %v, want ErrDecode", err)
+		}
+	})
+}
+
+func TestDecoderOptionalIsOneShot(t *testing.T) {
+	d := &decoder{endpoint: "Test", index: -1, strict: true}
+
+	d.optional().int("a", "", 10)
+	if d.err != nil {
+		t.Fatalf("optional field failed: %v", d.err)
+	}
+	d.int("b", "", 10)
+	if d.err == nil || d.err.(*DecodeError).Field != "b" {
+		t.Errorf("got error %v, want one for b", d.err)
+	}
+}
+
+func TestDecoderEmptyHex(t *testing.T) {
+	d := &decoder{endpoint: "Test", index: -1, strict: true}
+	if n := d.bigInt("result", "0x", 16); n != nil || !errors.Is(d.err, errMissing) {
+		t.Errorf("got %v, %v, want a missing value", n, d.err)
+	}
+
+	d = &decoder{endpoint: "Test", index: -1, strict: true}
+	if b := d.bytes("input", ""); len(b) != 0 || d.err != nil {
+		t.Errorf("got %x, %v, want no bytes", b, d.err)
+	}
+}
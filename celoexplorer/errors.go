@@ -0,0 +1,160 @@
+package celoexplorer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors. Use errors.Is to check the kind of an error returned by RequestClient or Client.
+var (
+	// The explorer has no record of what was asked for, e.g. "No transactions found" or a 404.
+	ErrNotFound = errors.New("celoexplorer: not found")
+	// The explorer is throttling requests, either with a 429 or a rate limit message.
+	ErrRateLimited = errors.New("celoexplorer: rate limited")
+	// The explorer answered with a 5xx status code.
+	ErrServer = errors.New("celoexplorer: server error")
+	// The response body could not be decoded.
+	ErrDecode = errors.New("celoexplorer: cannot decode response")
+)
+
+// APIError is returned when the explorer answers a request with anything other than a successful result.
+type APIError struct {
+	// HTTP status code of the response.
+	StatusCode int
+	// Blockscout `status` field, usually "0" on error. Empty for JSON RPC style endpoints.
+	Status string
+	// Blockscout `message` field, or the `error` field for JSON RPC style endpoints.
+	Message string
+	// Raw response body.
+	Body []byte
+	// Module and action of the endpoint, e.g. "account" and "txlist".
+	Module string
+	Action string
+	// Delay requested by the explorer through the Retry-After header, zero if absent.
+	RetryAfter time.Duration
+	// Kind of error, one of the sentinel errors above. Nil if the error cannot be classified.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("celoexplorer: ")
+	sb.WriteString(e.Module)
+	sb.WriteString(".")
+	sb.WriteString(e.Action)
+	sb.WriteString(": ")
+
+	switch {
+	case e.Message != "":
+		sb.WriteString(e.Message)
+	case e.Err != nil:
+		sb.WriteString(strings.TrimPrefix(e.Err.Error(), "celoexplorer: "))
+	default:
+		sb.WriteString(http.StatusText(e.StatusCode))
+	}
+
+	if e.StatusCode != 0 && e.StatusCode != http.StatusOK {
+		sb.WriteString(fmt.Sprintf(" (HTTP %d)", e.StatusCode))
+	}
+	return sb.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+func newAPIError(u *url.URL, resp *http.Response, body []byte) *APIError {
+	q := u.Query()
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		Module:     q.Get("module"),
+		Action:     q.Get("action"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// Retry-After is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// Fill in the kind of error from the status code and message.
+func (e *APIError) classify() *APIError {
+	msg := strings.ToLower(e.Message)
+
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests || strings.Contains(msg, "rate limit"):
+		e.Err = ErrRateLimited
+	case e.StatusCode >= 500:
+		e.Err = ErrServer
+	case e.StatusCode == http.StatusNotFound || isNotFoundMessage(msg):
+		e.Err = ErrNotFound
+	}
+	return e
+}
+
+// Blockscout phrases missing data as "No transactions found", "Transaction not found", "Contract source code not verified", etc.
+func isNotFoundMessage(msg string) bool {
+	if strings.Contains(msg, "not found") || strings.Contains(msg, "not verified") {
+		return true
+	}
+	return strings.HasPrefix(msg, "no ") && strings.HasSuffix(msg, "found")
+}
+
+// Body was received but could not be decoded.
+func newDecodeError(u *url.URL, resp *http.Response, body []byte, err error) *APIError {
+	e := newAPIError(u, resp, body)
+	e.Err = fmt.Errorf("%w: %v", ErrDecode, err)
+	return e
+}
+
+// Messages Blockscout sends with status "0" when a list endpoint simply has nothing to return, keyed by module and action.
+var emptyResultMessages = map[string][]string{
+	"account.pendingtxlist":  {"No transactions found"},
+	"account.txlist":         {"No transactions found"},
+	"account.txlistinternal": {"No internal transactions found"},
+	"account.tokentx":        {"No token transfers found"},
+	"account.tokenlist":      {"No tokens found"},
+	"account.getminedblocks": {"No blocks found"},
+	"account.listaccounts":   {"No accounts found"},
+	"logs.getLogs":           {"No logs found"},
+	"token.getTokenHolders":  {"No token holders found"},
+	"contract.listcontracts": {"No contracts found"},
+}
+
+// Whether err is the explorer saying a list endpoint has no results.
+func isEmptyResult(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != "0" {
+		return false
+	}
+
+	for _, msg := range emptyResultMessages[apiErr.Module+"."+apiErr.Action] {
+		if strings.EqualFold(apiErr.Message, msg) {
+			return true
+		}
+	}
+	return false
+}
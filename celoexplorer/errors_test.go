@@ -0,0 +1,147 @@
+package celoexplorer
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Status "0" bodies as recorded from Blockscout, keyed by module and action.
+var recordedEmptyResults = map[string]string{
+	"account.pendingtxlist":  `{"message":"No transactions found","result":[],"status":"0"}`,
+	"account.txlist":         `{"message":"No transactions found","result":[],"status":"0"}`,
+	"account.txlistinternal": `{"message":"No internal transactions found","result":[],"status":"0"}`,
+	"account.tokentx":        `{"message":"No token transfers found","result":[],"status":"0"}`,
+	"account.tokenlist":      `{"message":"No tokens found","result":[],"status":"0"}`,
+	"account.getminedblocks": `{"message":"No blocks found","result":[],"status":"0"}`,
+	"account.listaccounts":   `{"message":"No accounts found","result":[],"status":"0"}`,
+	"logs.getLogs":           `{"message":"No logs found","result":[],"status":"0"}`,
+	"token.getTokenHolders":  `{"message":"No token holders found","result":[],"status":"0"}`,
+	"contract.listcontracts": `{"message":"No contracts found","result":[],"status":"0"}`,
+}
+
+// Client whose server answers every request with the body recorded for its module and action.
+func newRecordedClient(t *testing.T, bodies map[string]string) *RequestClient {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		body, ok := bodies[q.Get("module")+"."+q.Get("action")]
+		if !ok {
+			http.Error(w, "no recorded body", http.StatusNotImplemented)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	r, err := NewRequestClientWithHttp(srv.URL+"/api", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetRetryPolicy(NoRetry)
+	return r
+}
+
+// List endpoints by module and action, returning the number of results.
+func listCalls(r *RequestClient) map[string]func() (int, error) {
+	address := MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438")
+	hash := MustParseHash("0x6d1c4bcb9b0c46a2a9e0b3a01a8e3a9ba7fd0e3b2a8a1ec1ec3f6bc7a5b1e2c3")
+	block := BlockRangeAdv{FromBlock: big.NewInt(0), ToLatest: true}
+
+	return map[string]func() (int, error){
+		"account.pendingtxlist": func() (int, error) {
+			x, err := r.PendingTxList(address, nil)
+			return len(x), err
+		},
+		"account.txlist": func() (int, error) {
+			x, err := r.TxList(address, nil, nil, nil, nil, nil)
+			return len(x), err
+		},
+		"account.txlistinternal": func() (int, error) {
+			x, err := r.TxListInternalByHash(hash, nil)
+			return len(x), err
+		},
+		"account.tokentx": func() (int, error) {
+			x, err := r.TokenTx(address, nil, nil, nil, nil)
+			return len(x), err
+		},
+		"account.tokenlist": func() (int, error) {
+			x, err := r.TokenList(address)
+			return len(x), err
+		},
+		"account.getminedblocks": func() (int, error) {
+			x, err := r.GetMinedBlocks(address, nil)
+			return len(x), err
+		},
+		"account.listaccounts": func() (int, error) {
+			x, err := r.ListAccounts(nil)
+			return len(x), err
+		},
+		"logs.getLogs": func() (int, error) {
+			x, err := r.GetLogs(block, address, Topics{})
+			return len(x), err
+		},
+		"token.getTokenHolders": func() (int, error) {
+			x, err := r.GetTokenHolders(address, nil)
+			return len(x), err
+		},
+		"contract.listcontracts": func() (int, error) {
+			x, err := r.ListContracts(nil, nil, nil)
+			return len(x), err
+		},
+	}
+}
+
+func TestEmptyResultMessages(t *testing.T) {
+	r := newRecordedClient(t, recordedEmptyResults)
+	calls := listCalls(r)
+
+	for key := range emptyResultMessages {
+		if _, ok := recordedEmptyResults[key]; !ok {
+			t.Errorf("%s: no recorded body", key)
+		}
+	}
+
+	for key := range recordedEmptyResults {
+		call, ok := calls[key]
+		if !ok {
+			t.Errorf("%s: no call", key)
+			continue
+		}
+
+		t.Run(key, func(t *testing.T) {
+			n, err := call()
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if n != 0 {
+				t.Errorf("got %d results, want 0", n)
+			}
+		})
+	}
+}
+
+func TestNonEmptyResultFailure(t *testing.T) {
+	bodies := map[string]string{
+		"account.txlist": `{"message":"Invalid address format","result":null,"status":"0"}`,
+		// the empty message of another endpoint is still a failure
+		"account.tokentx": `{"message":"No logs found","result":null,"status":"0"}`,
+	}
+	calls := listCalls(newRecordedClient(t, bodies))
+
+	for key := range bodies {
+		t.Run(key, func(t *testing.T) {
+			_, err := calls[key]()
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want *APIError", err)
+			}
+			if apiErr.Status != "0" || apiErr.Module+"."+apiErr.Action != key {
+				t.Errorf("got status %q for %s.%s, want status \"0\" for %s", apiErr.Status, apiErr.Module, apiErr.Action, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package celoexplorer
+
+import (
+	"context"
+	"math/big"
+	"sort"
+)
+
+type TokenHolder struct {
+	Address Address
+	Balance *big.Int
+}
+
+// Get token holders by contract address. Up to a maximum of 10,000 holders per page.
+func (c *Client) TokenHolders(contractAddress Address, page *PageRange) ([]TokenHolder, error) {
+	return c.TokenHoldersCtx(context.Background(), contractAddress, page)
+}
+
+// TokenHoldersCtx is TokenHolders with a context.
+func (c *Client) TokenHoldersCtx(ctx context.Context, contractAddress Address, page *PageRange) ([]TokenHolder, error) {
+	holderList, err := c.req.GetTokenHoldersCtx(ctx, contractAddress, page)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("GetTokenHolders")
+	holders := make([]TokenHolder, len(holderList))
+	for i, v := range holderList {
+		d.at(i)
+		holders[i].Address = d.address("address", v.Address)
+		holders[i].Balance = d.bigInt("value", v.Value, 10)
+	}
+	return holders, d.err
+}
+
+// HolderShare is one holder in a HolderDistribution.
+type HolderShare struct {
+	TokenHolder
+	// Balance in whole tokens, scaled by the token's decimals.
+	Amount *big.Float
+	// Percentage of the total supply, from 0 to 100.
+	Share float64
+}
+
+// HolderDistribution describes how the supply of a token is spread over its holders.
+type HolderDistribution struct {
+	Token       TokenInfo
+	TotalSupply *big.Int
+	// Number of addresses holding a non-zero balance.
+	Holders int
+	// Largest holders, largest first.
+	Top []HolderShare
+	// Percentage of the total supply held by Top.
+	TopShare float64
+	// Percentage of the total supply held by all holders. Tokens held by contracts the explorer does not index, or burnt, make this less than 100.
+	HeldShare float64
+	// Gini coefficient of the holder balances, from 0 when all holders hold the same to close to 1 when one holder holds everything.
+	Gini float64
+}
+
+// Walk through every holder of a token and summarise the distribution of its supply, keeping the top largest holders.
+func (c *Client) TokenHolderDistribution(contractAddress Address, top int) (HolderDistribution, error) {
+	return c.TokenHolderDistributionCtx(context.Background(), contractAddress, top)
+}
+
+// TokenHolderDistributionCtx is TokenHolderDistribution with a context.
+func (c *Client) TokenHolderDistributionCtx(ctx context.Context, contractAddress Address, top int) (HolderDistribution, error) {
+	token, err := c.GetTokenCtx(ctx, contractAddress)
+	if err != nil {
+		return HolderDistribution{}, err
+	}
+
+	totalSupply, err := c.TokenSupplyCtx(ctx, contractAddress)
+	if err != nil {
+		return HolderDistribution{}, err
+	}
+
+	var holders []TokenHolder
+	it := c.TokenHoldersIterator(ctx, contractAddress, 0)
+	for it.Next() {
+		h := it.Value()
+		if h.Balance != nil && h.Balance.Sign() > 0 {
+			holders = append(holders, h)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return HolderDistribution{}, err
+	}
+
+	return distribution(token, totalSupply, holders, top), nil
+}
+
+func distribution(token TokenInfo, totalSupply *big.Int, holders []TokenHolder, top int) HolderDistribution {
+	if totalSupply == nil {
+		// fall back to the supply reported with the token
+		totalSupply = token.TotalSupply
+	}
+
+	sort.SliceStable(holders, func(i, j int) bool {
+		return holders[i].Balance.Cmp(holders[j].Balance) > 0
+	})
+
+	dist := HolderDistribution{
+		Token:       token,
+		TotalSupply: totalSupply,
+		Holders:     len(holders),
+	}
+
+	if top < 0 {
+		top = 0
+	}
+	if top > len(holders) {
+		top = len(holders)
+	}
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil))
+	topSum := new(big.Int)
+	for _, h := range holders[:top] {
+		dist.Top = append(dist.Top, HolderShare{
+			TokenHolder: h,
+			Amount:      new(big.Float).Quo(new(big.Float).SetInt(h.Balance), scale),
+			Share:       percentage(h.Balance, totalSupply),
+		})
+		topSum.Add(topSum, h.Balance)
+	}
+	dist.TopShare = percentage(topSum, totalSupply)
+
+	// with balances x_1 >= ... >= x_n, G = (n + 1 - 2 * sum(i * x_i) / sum(x_i)) / n
+	sum, weighted := new(big.Int), new(big.Int)
+	for i, h := range holders {
+		sum.Add(sum, h.Balance)
+		weighted.Add(weighted, new(big.Int).Mul(big.NewInt(int64(i+1)), h.Balance))
+	}
+	dist.HeldShare = percentage(sum, totalSupply)
+	if n := len(holders); n > 0 {
+		ratio, _ := new(big.Rat).SetFrac(weighted, sum).Float64()
+		dist.Gini = (float64(n+1) - 2*ratio) / float64(n)
+	}
+	return dist
+}
+
+// Percentage part is of whole, 0 if whole is unknown or zero.
+func percentage(part, whole *big.Int) float64 {
+	if whole == nil || whole.Sign() == 0 {
+		return 0
+	}
+	p, _ := new(big.Rat).SetFrac(new(big.Int).Mul(part, big.NewInt(100)), whole).Float64()
+	return p
+}
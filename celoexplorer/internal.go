@@ -0,0 +1,261 @@
+package celoexplorer
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+)
+
+type callKindType string
+
+// Kinds of internal transactions.
+var CallKind = struct {
+	Call         callKindType
+	StaticCall   callKindType
+	DelegateCall callKindType
+	CallCode     callKindType
+	Create       callKindType
+	Create2      callKindType
+	SelfDestruct callKindType
+	Reward       callKindType
+}{
+	Call:         "call",
+	StaticCall:   "staticcall",
+	DelegateCall: "delegatecall",
+	CallCode:     "callcode",
+	Create:       "create",
+	Create2:      "create2",
+	SelfDestruct: "selfdestruct",
+	Reward:       "reward",
+}
+
+type InternalTransaction struct {
+	BlockNumber *big.Int
+	// Address of the created contract for create and create2.
+	ContractAddress Address
+	ErrCode         string
+	From            Address
+	Gas             uint64
+	GasUsed         uint64
+	// Position in the execution trace of the transaction, starting at 0 for the top level call.
+	Index           int
+	Input           []byte
+	IsError         bool
+	Kind            callKindType
+	Timestamp       time.Time
+	To              Address
+	TransactionHash Hash
+	Value           *big.Int
+}
+
+// Get internal transactions by transaction or address hash. Up to a maximum of 10,000 internal transactions.
+func (c *Client) InternalTransactions(txHash Hash, address *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]InternalTransaction, error) {
+	return c.InternalTransactionsCtx(context.Background(), txHash, address, sort, block, page)
+}
+
+// InternalTransactionsCtx is InternalTransactions with a context.
+func (c *Client) InternalTransactionsCtx(ctx context.Context, txHash Hash, address *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]InternalTransaction, error) {
+	internalList, err := c.req.TxListInternalCtx(ctx, txHash, address, sort, block, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.internalTransactions("TxListInternal", internalList)
+}
+
+// Get internal transactions of a transaction.
+func (c *Client) InternalTransactionsByHash(txHash Hash, page *PageRange) ([]InternalTransaction, error) {
+	return c.InternalTransactionsByHashCtx(context.Background(), txHash, page)
+}
+
+// InternalTransactionsByHashCtx is InternalTransactionsByHash with a context.
+func (c *Client) InternalTransactionsByHashCtx(ctx context.Context, txHash Hash, page *PageRange) ([]InternalTransaction, error) {
+	internalList, err := c.req.TxListInternalByHashCtx(ctx, txHash, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.internalTransactions("TxListInternalByHash", internalList)
+}
+
+// Get internal transactions to or from an address, such as CELO received through contract calls. Up to a maximum of 10,000 internal transactions.
+func (c *Client) InternalTransactionsByAddress(address Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]InternalTransaction, error) {
+	return c.InternalTransactionsByAddressCtx(context.Background(), address, sort, block, page)
+}
+
+// InternalTransactionsByAddressCtx is InternalTransactionsByAddress with a context.
+func (c *Client) InternalTransactionsByAddressCtx(ctx context.Context, address Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]InternalTransaction, error) {
+	internalList, err := c.req.TxListInternalByAddressCtx(ctx, address, sort, block, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.internalTransactions("TxListInternalByAddress", internalList)
+}
+
+func (c *Client) internalTransactions(endpoint string, internalList []TxListInternal) ([]InternalTransaction, error) {
+	d := c.decoder(endpoint)
+	internal := make([]InternalTransaction, len(internalList))
+	for i, v := range internalList {
+		d.at(i)
+		internal[i].BlockNumber = d.bigInt("blockNumber", v.Blocknumber, 10)
+		internal[i].ContractAddress = d.optional().address("contractAddress", v.Contractaddress)
+		internal[i].ErrCode = v.Errcode
+		internal[i].From = d.address("from", v.From)
+		internal[i].Gas = d.uint64("gas", v.Gas, 10)
+		internal[i].GasUsed = d.uint64("gasUsed", v.Gasused, 10)
+		internal[i].Index = d.int("index", v.Index, 10)
+		internal[i].Input = d.bytes("input", v.Input)
+		internal[i].IsError = v.Iserror != "0" && v.Iserror != ""
+		internal[i].Kind = callKind(v.Type, v.Calltype)
+		internal[i].Timestamp = d.unix("timeStamp", v.Timestamp, 10)
+		internal[i].To = d.optional().address("to", v.To)
+		internal[i].TransactionHash = d.hash("transactionHash", v.Transactionhash)
+		internal[i].Value = d.bigInt("value", v.Value, 10)
+	}
+	return internal, d.err
+}
+
+// Blockscout reports every kind of call as "call" and puts the actual kind in callType.
+func callKind(typ, callType string) callKindType {
+	if typ == "call" && callType != "" {
+		return callKindType(callType)
+	}
+	return callKindType(typ)
+}
+
+// CallFrame is one call of a transaction's call tree.
+type CallFrame struct {
+	InternalTransaction
+	// Zero for the top level call.
+	Depth int
+	// Calls made by this frame, in execution order.
+	Calls []*CallFrame
+}
+
+// Address whose code runs in this frame and so makes its nested calls. Zero if the frame cannot make calls.
+func (f *CallFrame) context() Address {
+	switch f.Kind {
+	case CallKind.Create, CallKind.Create2:
+		return f.ContractAddress
+	case CallKind.DelegateCall, CallKind.CallCode:
+		// the callee's code runs as the caller
+		return f.From
+	case CallKind.SelfDestruct, CallKind.Reward:
+		return Address{}
+	}
+	return f.To
+}
+
+// Call fn for the frame and every frame below it, depth first in execution order. Stops early if fn returns false.
+func (f *CallFrame) Walk(fn func(frame *CallFrame) bool) bool {
+	if !fn(f) {
+		return false
+	}
+	for _, call := range f.Calls {
+		if !call.Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Value moved by the frame and every frame below it that did not fail.
+func (f *CallFrame) TotalValue() *big.Int {
+	total := new(big.Int)
+	f.Walk(func(frame *CallFrame) bool {
+		if !frame.IsError && frame.Value != nil {
+			total.Add(total, frame.Value)
+		}
+		return true
+	})
+	return total
+}
+
+// Get the call tree of a transaction from its internal transactions.
+// The explorer does not say which call made which, so every frame is attached to the innermost open frame whose code runs at the frame's sender address.
+// A contract that calls itself recursively may therefore be nested deeper than it really was.
+func (c *Client) CallTree(txHash Hash) (*CallFrame, error) {
+	return c.CallTreeCtx(context.Background(), txHash)
+}
+
+// CallTreeCtx is CallTree with a context.
+func (c *Client) CallTreeCtx(ctx context.Context, txHash Hash) (*CallFrame, error) {
+	internal, err := c.InternalTransactionsByHashCtx(ctx, txHash, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range internal {
+		if t.Index == 0 {
+			return buildCallTree(nil, internal), nil
+		}
+	}
+
+	// the explorer usually leaves out the top level call, so rebuild it from the transaction
+	tx, err := c.GetTxInfoCtx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	return buildCallTree(topLevelFrame(tx), internal), nil
+}
+
+// Frame of the call a transaction makes to its target.
+func topLevelFrame(tx TransactionWithLogs) *CallFrame {
+	kind := CallKind.Call
+	if tx.To.IsZero() {
+		kind = CallKind.Create
+	}
+
+	var gas uint64
+	if tx.GasLimit != nil && tx.GasLimit.IsUint64() {
+		gas = tx.GasLimit.Uint64()
+	}
+
+	return &CallFrame{InternalTransaction: InternalTransaction{
+		BlockNumber:     tx.BlockNumber,
+		From:            tx.From,
+		Gas:             gas,
+		GasUsed:         uint64(tx.GasUsed),
+		Input:           tx.Input,
+		IsError:         !tx.Success,
+		Kind:            kind,
+		Timestamp:       tx.Timestamp,
+		To:              tx.To,
+		TransactionHash: tx.Hash,
+		Value:           tx.Value,
+	}}
+}
+
+// Arrange internal under root, or under the frame with index 0 when root is nil.
+func buildCallTree(root *CallFrame, internal []InternalTransaction) *CallFrame {
+	frames := make([]*CallFrame, len(internal))
+	for i := range internal {
+		frames[i] = &CallFrame{InternalTransaction: internal[i]}
+	}
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].Index < frames[j].Index
+	})
+
+	if root == nil {
+		root, frames = frames[0], frames[1:]
+	}
+
+	stack := []*CallFrame{root}
+	for _, frame := range frames {
+		// close frames until reaching the one that made this call; the root stays open
+		for len(stack) > 1 && stack[len(stack)-1].context() != frame.From {
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := stack[len(stack)-1]
+		frame.Depth = parent.Depth + 1
+		parent.Calls = append(parent.Calls, frame)
+
+		if !frame.context().IsZero() {
+			stack = append(stack, frame)
+		}
+	}
+	return root
+}
@@ -0,0 +1,109 @@
+package celoexplorer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Tree as "to(child,child)" with addresses shortened to their last byte.
+func treeShape(f *CallFrame) string {
+	shape := fmt.Sprintf("%x", f.To[len(f.To)-1])
+	if len(f.Calls) == 0 {
+		return shape
+	}
+
+	calls := make([]string, len(f.Calls))
+	for i, c := range f.Calls {
+		if c.Depth != f.Depth+1 {
+			return fmt.Sprintf("bad depth %d under %d", c.Depth, f.Depth)
+		}
+		calls[i] = treeShape(c)
+	}
+	return shape + "(" + strings.Join(calls, ",") + ")"
+}
+
+func TestBuildCallTree(t *testing.T) {
+	a, b, c, d := Address{19: 0xa}, Address{19: 0xb}, Address{19: 0xc}, Address{19: 0xd}
+	eoa := Address{19: 0xe}
+	frame := func(index int, kind callKindType, from, to Address) InternalTransaction {
+		return InternalTransaction{Index: index, Kind: kind, From: from, To: to}
+	}
+	top := &CallFrame{InternalTransaction: frame(0, CallKind.Call, eoa, a)}
+
+	tests := []struct {
+		name     string
+		root     *CallFrame
+		internal []InternalTransaction
+		want     string
+	}{
+		{
+			name: "siblings without top level frame",
+			root: top,
+			internal: []InternalTransaction{
+				frame(1, CallKind.Call, a, b),
+				frame(2, CallKind.Call, a, c),
+			},
+			want: "a(b,c)",
+		},
+		{
+			name: "siblings with top level frame",
+			internal: []InternalTransaction{
+				frame(2, CallKind.Call, a, c),
+				frame(0, CallKind.Call, eoa, a),
+				frame(1, CallKind.Call, a, b),
+			},
+			want: "a(b,c)",
+		},
+		{
+			name: "nested call returns to caller",
+			root: top,
+			internal: []InternalTransaction{
+				frame(1, CallKind.Call, a, b),
+				frame(2, CallKind.Call, b, c),
+				frame(3, CallKind.Call, a, d),
+			},
+			want: "a(b(c),d)",
+		},
+		{
+			name: "delegatecall runs as caller",
+			root: top,
+			internal: []InternalTransaction{
+				frame(1, CallKind.DelegateCall, a, b),
+				frame(2, CallKind.Call, a, c),
+			},
+			want: "a(b(c))",
+		},
+		{
+			name: "unknown sender hangs under root",
+			root: top,
+			internal: []InternalTransaction{
+				frame(1, CallKind.DelegateCall, a, b),
+				frame(2, CallKind.Call, a, c),
+				frame(3, CallKind.Call, c, d),
+				frame(4, CallKind.StaticCall, b, d),
+			},
+			want: "a(b(c(d)),d)",
+		},
+		{
+			name: "top level frame only",
+			root: top,
+			want: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var root *CallFrame
+			if tt.root != nil {
+				copied := *tt.root
+				root = &copied
+			}
+
+			got := treeShape(buildCallTree(root, tt.internal))
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
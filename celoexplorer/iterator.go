@@ -0,0 +1,273 @@
+package celoexplorer
+
+import "context"
+
+// Page size used by iterators when none is given.
+const defaultPageSize = 1000
+
+// Page by page walk shared by the iterators. fetch loads a page into the iterator's buffer and returns its length.
+type pager struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page PageRange) (int, error)
+	page  int
+	size  int
+	pos   int
+	n     int
+	done  bool
+	err   error
+}
+
+func newPager(ctx context.Context, pageSize int, fetch func(ctx context.Context, page PageRange) (int, error)) pager {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return pager{
+		ctx:   ctx,
+		fetch: fetch,
+		page:  1,
+		size:  pageSize,
+	}
+}
+
+// Index of the next item in the current page, loading the next page when needed.
+func (p *pager) next() (int, bool) {
+	if p.pos < p.n {
+		p.pos++
+		return p.pos - 1, true
+	}
+
+	if p.done || p.err != nil {
+		return 0, false
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return 0, false
+	}
+
+	n, err := p.fetch(p.ctx, PageRange{Page: p.page, Offset: p.size})
+	if err != nil {
+		p.err = err
+		return 0, false
+	}
+
+	p.page++
+	p.n = n
+	p.pos = 0
+	// a short page is the last one
+	if n < p.size {
+		p.done = true
+	}
+	if n == 0 {
+		return 0, false
+	}
+
+	p.pos = 1
+	return 0, true
+}
+
+// Error that stopped the iteration, nil if it ran to the end.
+func (p *pager) Err() error {
+	return p.err
+}
+
+// TxListIterator walks through the transactions of an address, fetching one page at a time.
+type TxListIterator struct {
+	pager
+	items []Transaction
+	cur   Transaction
+}
+
+// Iterate over all transactions of an address. Arguments are the same as TxList; pageSize defaults to 1,000 when not positive.
+//
+//	it := client.TxListIterator(ctx, address, nil, nil, nil, nil, 0)
+//	for it.Next() {
+//		tx := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (c *Client) TxListIterator(ctx context.Context, address Address, sort *sortDirectionType, block *BlockRange, filter *filterDirectionType, timeRange *TimeRange, pageSize int) *TxListIterator {
+	it := &TxListIterator{}
+	it.pager = newPager(ctx, pageSize, func(ctx context.Context, page PageRange) (int, error) {
+		txs, err := c.TxListCtx(ctx, address, sort, block, &page, filter, timeRange)
+		it.items = txs
+		return len(txs), err
+	})
+	return it
+}
+
+// Advance to the next transaction. Returns false when there are no more transactions or an error occurred.
+func (it *TxListIterator) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Current transaction.
+func (it *TxListIterator) Value() Transaction {
+	return it.cur
+}
+
+// PendingTransactionIterator walks through the pending transactions of an address, fetching one page at a time.
+type PendingTransactionIterator struct {
+	pager
+	items []PendingTransaction
+	cur   PendingTransaction
+}
+
+// Iterate over all pending transactions of an address. pageSize defaults to 1,000 when not positive.
+func (c *Client) PendingTransactionsIterator(ctx context.Context, address Address, pageSize int) *PendingTransactionIterator {
+	it := &PendingTransactionIterator{}
+	it.pager = newPager(ctx, pageSize, func(ctx context.Context, page PageRange) (int, error) {
+		txs, err := c.PendingTransactionsCtx(ctx, address, &page)
+		it.items = txs
+		return len(txs), err
+	})
+	return it
+}
+
+// Advance to the next pending transaction. Returns false when there are no more transactions or an error occurred.
+func (it *PendingTransactionIterator) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Current pending transaction.
+func (it *PendingTransactionIterator) Value() PendingTransaction {
+	return it.cur
+}
+
+// InternalTransactionIterator walks through the internal transactions of an address, fetching one page at a time.
+type InternalTransactionIterator struct {
+	pager
+	items []InternalTransaction
+	cur   InternalTransaction
+}
+
+// Iterate over all internal transactions to or from an address. Arguments are the same as InternalTransactionsByAddress; pageSize defaults to 1,000 when not positive.
+func (c *Client) InternalTransactionsIterator(ctx context.Context, address Address, sort *sortDirectionType, block *BlockRange, pageSize int) *InternalTransactionIterator {
+	it := &InternalTransactionIterator{}
+	it.pager = newPager(ctx, pageSize, func(ctx context.Context, page PageRange) (int, error) {
+		txs, err := c.InternalTransactionsByAddressCtx(ctx, address, sort, block, &page)
+		it.items = txs
+		return len(txs), err
+	})
+	return it
+}
+
+// Advance to the next internal transaction. Returns false when there are no more transactions or an error occurred.
+func (it *InternalTransactionIterator) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Current internal transaction.
+func (it *InternalTransactionIterator) Value() InternalTransaction {
+	return it.cur
+}
+
+// TokenHolderIterator walks through the holders of a token, fetching one page at a time.
+type TokenHolderIterator struct {
+	pager
+	items []TokenHolder
+	cur   TokenHolder
+}
+
+// Iterate over all holders of a token. pageSize defaults to 1,000 when not positive.
+func (c *Client) TokenHoldersIterator(ctx context.Context, contractAddress Address, pageSize int) *TokenHolderIterator {
+	it := &TokenHolderIterator{}
+	it.pager = newPager(ctx, pageSize, func(ctx context.Context, page PageRange) (int, error) {
+		holders, err := c.TokenHoldersCtx(ctx, contractAddress, &page)
+		it.items = holders
+		return len(holders), err
+	})
+	return it
+}
+
+// Advance to the next holder. Returns false when there are no more holders or an error occurred.
+func (it *TokenHolderIterator) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Current holder.
+func (it *TokenHolderIterator) Value() TokenHolder {
+	return it.cur
+}
+
+// MinedBlockIterator walks through the blocks mined by an address, fetching one page at a time.
+type MinedBlockIterator struct {
+	pager
+	items []MinedBlock
+	cur   MinedBlock
+}
+
+// Iterate over all blocks mined by signer. pageSize defaults to 1,000 when not positive.
+func (c *Client) MinedBlocksIterator(ctx context.Context, signer Address, pageSize int) *MinedBlockIterator {
+	it := &MinedBlockIterator{}
+	it.pager = newPager(ctx, pageSize, func(ctx context.Context, page PageRange) (int, error) {
+		blocks, err := c.MinedBlocksCtx(ctx, signer, &page)
+		it.items = blocks
+		return len(blocks), err
+	})
+	return it
+}
+
+// Advance to the next block. Returns false when there are no more blocks or an error occurred.
+func (it *MinedBlockIterator) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Current block.
+func (it *MinedBlockIterator) Value() MinedBlock {
+	return it.cur
+}
+
+// ContractIterator walks through the contracts matching a filter, fetching one page at a time.
+type ContractIterator struct {
+	pager
+	items []Contract
+	cur   Contract
+}
+
+// Iterate over all contracts matching filter. Arguments are the same as ListContracts; pageSize defaults to 1,000 when not positive.
+func (c *Client) ContractsIterator(ctx context.Context, filter *filterContractType, notVersion *string, pageSize int) *ContractIterator {
+	it := &ContractIterator{}
+	it.pager = newPager(ctx, pageSize, func(ctx context.Context, page PageRange) (int, error) {
+		contracts, err := c.ListContractsCtx(ctx, &page, filter, notVersion)
+		it.items = contracts
+		return len(contracts), err
+	})
+	return it
+}
+
+// Advance to the next contract. Returns false when there are no more contracts or an error occurred.
+func (it *ContractIterator) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.items[i]
+	}
+	return ok
+}
+
+// Current contract.
+func (it *ContractIterator) Value() Contract {
+	return it.cur
+}
@@ -0,0 +1,115 @@
+package celoexplorer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Doer sends an http request. *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc lets an ordinary function act as a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to inspect or change requests and responses.
+type Middleware func(next Doer) Doer
+
+// Wrap doer with middlewares. The first middleware is the outermost, so it sees the request first and the response last.
+func Chain(doer Doer, middlewares ...Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// Log the url, status code and duration of every request.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			if err != nil {
+				logger.Printf("celoexplorer: %s %s failed after %v: %v", req.Method, redactedUrl(req), time.Since(start), err)
+				return resp, err
+			}
+
+			logger.Printf("celoexplorer: %s %s %d in %v", req.Method, redactedUrl(req), resp.StatusCode, time.Since(start))
+			return resp, nil
+		})
+	}
+}
+
+// Url of req without the api key.
+func redactedUrl(req *http.Request) string {
+	u := cloneUrl(req.URL)
+	q := u.Query()
+	if q.Get("apikey") != "" {
+		q.Set("apikey", "REDACTED")
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// Set the given headers on every request, replacing existing values.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, values := range header {
+				req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a context that makes RequestIDMiddleware send id with calls using it.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Request id stored in ctx by ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// Send the request id of the request context in the named header, "X-Request-Id" if empty.
+// A random id is generated for requests whose context has none.
+func RequestIDMiddleware(header string) Middleware {
+	if header == "" {
+		header = "X-Request-Id"
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			id, ok := RequestIDFromContext(req.Context())
+			if !ok {
+				id = newRequestID()
+			}
+
+			req = req.Clone(req.Context())
+			req.Header.Set(header, id)
+			return next.Do(req)
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
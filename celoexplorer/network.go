@@ -0,0 +1,124 @@
+package celoexplorer
+
+import "strings"
+
+// Network is a Celo network that has a Blockscout explorer.
+type Network struct {
+	Name    string
+	ChainID int64
+	// Base url of the explorer API, ending in /api.
+	APIURL string
+	// Json rpc endpoint of a public node.
+	RPCURL string
+	// Core token contracts by symbol: CELO, cUSD and cEUR. Unexported so that the predefined networks cannot be changed through their shared map.
+	tokens map[string]Address
+}
+
+var (
+	Mainnet = Network{
+		Name:    "mainnet",
+		ChainID: 42220,
+		APIURL:  BaseUrl,
+		RPCURL:  "https://forno.celo.org",
+		tokens: map[string]Address{
+			"CELO": CeloGold,
+			"cUSD": CeloUSD,
+			"cEUR": CeloEUR,
+		},
+	}
+
+	Alfajores = Network{
+		Name:    "alfajores",
+		ChainID: 44787,
+		APIURL:  TestnetBaseUrl,
+		RPCURL:  "https://alfajores-forno.celo-testnet.org",
+		tokens: map[string]Address{
+			"CELO": TestnetCeloGold,
+			"cUSD": TestnetCeloUSD,
+			"cEUR": TestnetCeloEUR,
+		},
+	}
+
+	Baklava = Network{
+		Name:    "baklava",
+		ChainID: 62320,
+		APIURL:  "https://baklava-blockscout.celo-testnet.org/api",
+		RPCURL:  "https://baklava-forno.celo-testnet.org",
+		tokens: map[string]Address{
+			"CELO": MustParseAddress("0xddc9be57f553fe75752d61606b94cbd7e0264ef8"),
+			"cUSD": MustParseAddress("0x62492a644a588fd904270bed06ad52b9abfea1ae"),
+			"cEUR": MustParseAddress("0xf9ece301247ad2ce21894941830a2470f4e774ca"),
+		},
+	}
+)
+
+// Predefined networks.
+func Networks() []Network {
+	return []Network{Mainnet, Alfajores, Baklava}
+}
+
+// Predefined network by name, ignoring case.
+func NetworkByName(name string) (Network, bool) {
+	for _, n := range Networks() {
+		if strings.EqualFold(n.Name, name) {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+// Predefined network by chain id.
+func NetworkByChainID(chainID int64) (Network, bool) {
+	for _, n := range Networks() {
+		if n.ChainID == chainID {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+// Contract address of a core token by symbol, ignoring case. "CGLD" and "GOLD" are accepted for CELO.
+func (n Network) Token(symbol string) (Address, bool) {
+	switch strings.ToUpper(symbol) {
+	case "CGLD", "GOLD":
+		symbol = "CELO"
+	}
+
+	for s, a := range n.tokens {
+		if strings.EqualFold(s, symbol) {
+			return a, true
+		}
+	}
+	return Address{}, false
+}
+
+// Core token contracts of the network by symbol.
+func (n Network) Tokens() map[string]Address {
+	tokens := make(map[string]Address, len(n.tokens))
+	for s, a := range n.tokens {
+		tokens[s] = a
+	}
+	return tokens
+}
+
+// Copy of the network with tokens as its core token contracts, for networks that are not predefined.
+func (n Network) WithTokens(tokens map[string]Address) Network {
+	n.tokens = Network{tokens: tokens}.Tokens()
+	return n
+}
+
+// Create a client for the explorer of network.
+func NewForNetwork(network Network, opts ...Option) (*Client, error) {
+	// copy so that the caller's slice is left alone
+	all := make([]Option, 0, len(opts)+1)
+	all = append(all, opts...)
+	return New(network.APIURL, append(all, WithNetwork(network))...)
+}
+
+// Network the client was created for, if any.
+func (c *Client) Network() (Network, bool) {
+	if c.network == nil {
+		return Network{}, false
+	}
+	return *c.network, true
+}
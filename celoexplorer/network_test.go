@@ -0,0 +1,51 @@
+package celoexplorer
+
+import "testing"
+
+func TestNetworkTokensAreCopies(t *testing.T) {
+	tokens := Mainnet.Tokens()
+	tokens["CELO"] = Address{}
+	delete(tokens, "cUSD")
+
+	if a, ok := Mainnet.Token("celo"); !ok || a != CeloGold {
+		t.Errorf("Mainnet CELO changed to %s, %v", a, ok)
+	}
+	if a, ok := Mainnet.Token("cUSD"); !ok || a != CeloUSD {
+		t.Errorf("Mainnet cUSD changed to %s, %v", a, ok)
+	}
+
+	custom := Network{Name: "local"}.WithTokens(tokens)
+	tokens["cEUR"] = Address{}
+	if a, _ := custom.Token("cEUR"); a != CeloEUR {
+		t.Errorf("custom network cEUR changed to %s", a)
+	}
+}
+
+func TestNetworkLookup(t *testing.T) {
+	if n, ok := NetworkByName("ALFAJORES"); !ok || n.ChainID != 44787 {
+		t.Errorf("got %+v, %v", n, ok)
+	}
+	if n, ok := NetworkByChainID(62320); !ok || n.Name != "baklava" {
+		t.Errorf("got %+v, %v", n, ok)
+	}
+	if a, ok := Alfajores.Token("cGLD"); !ok || a != TestnetCeloGold {
+		t.Errorf("got %s, %v", a, ok)
+	}
+}
+
+func TestNewForNetworkLeavesOptionsAlone(t *testing.T) {
+	marker := WithUserAgent("marker")
+	opts := make([]Option, 1, 2)
+	opts[0] = WithTimeout(0)
+	spare := opts[:2]
+	spare[1] = marker
+
+	if _, err := NewForNetwork(Alfajores, opts...); err != nil {
+		t.Fatal(err)
+	}
+	var o options
+	spare[1](&o)
+	if o.userAgent != "marker" || o.network != nil {
+		t.Errorf("caller's backing array was overwritten")
+	}
+}
@@ -0,0 +1,211 @@
+package celoexplorer
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger receives one line for every request sent to the explorer. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Option configures a Client created by New.
+type Option func(*options)
+
+type options struct {
+	doer        Doer
+	middlewares []Middleware
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	timeout     time.Duration
+	userAgent   string
+	header      http.Header
+	apiKey      string
+	retry       *RetryPolicy
+	limiter     *RateLimiter
+	modLimiters map[string]*RateLimiter
+	logger      Logger
+	network     *Network
+	lenient     bool
+}
+
+// Send requests with doer instead of an http client. Takes precedence over WithHTTPClient, WithTransport and WithTimeout.
+func WithDoer(doer Doer) Option {
+	return func(o *options) {
+		o.doer = doer
+	}
+}
+
+// Wrap every request in middlewares. Can be given more than once; earlier middlewares are outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// Send requests with this http client instead of the default one.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// Send requests through this transport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// Time limit for a single request, including reading the response body. Zero means no limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// Set the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// Add a header to every request. Can be given more than once.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// Send this api key with every request.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// Retry failed requests according to policy. Without this option requests are sent once.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &policy
+	}
+}
+
+// Limit the request rate of the client. The limiter can be shared with other clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// Limit the request rate of one module of the API, such as "account", "logs" or "contract", on top of WithRateLimiter.
+// Can be given once per module.
+func WithModuleRateLimiter(module string, limiter *RateLimiter) Option {
+	return func(o *options) {
+		if o.modLimiters == nil {
+			o.modLimiters = make(map[string]*RateLimiter)
+		}
+		o.modLimiters[module] = limiter
+	}
+}
+
+// Log every request.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Talk to the explorer of network. Its url replaces the one given to New.
+func WithNetwork(network Network) Option {
+	return func(o *options) {
+		o.network = &network
+	}
+}
+
+// Ignore fields of a response that cannot be converted instead of failing with a DecodeError.
+// They are left at their zero value, which is how the client behaved before decoding was strict.
+func WithLenientDecoding() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// Same transport New has always used.
+func defaultHttpClient() *http.Client {
+	tr := &http.Transport{
+		MaxIdleConns:    100,
+		IdleConnTimeout: 30 * time.Second,
+	}
+	return &http.Client{Transport: tr}
+}
+
+func (o *options) buildHttpClient() *http.Client {
+	if o.httpClient == nil && o.transport == nil && o.timeout == 0 {
+		return defaultHttpClient()
+	}
+
+	var client http.Client
+	if o.httpClient != nil {
+		// copy so that the caller's client is left alone
+		client = *o.httpClient
+	} else {
+		client = *defaultHttpClient()
+	}
+
+	if o.transport != nil {
+		client.Transport = o.transport
+	}
+	if o.timeout != 0 {
+		client.Timeout = o.timeout
+	}
+	return &client
+}
+
+// The http client or doer wrapped in the configured middlewares, then headers, then logging.
+func (o *options) buildDoer() Doer {
+	var doer Doer = o.doer
+	if doer == nil {
+		doer = o.buildHttpClient()
+	}
+
+	middlewares := append([]Middleware(nil), o.middlewares...)
+	header := o.header.Clone()
+	if o.userAgent != "" {
+		if header == nil {
+			header = make(http.Header)
+		}
+		header.Set("User-Agent", o.userAgent)
+	}
+	if header != nil {
+		middlewares = append(middlewares, HeaderMiddleware(header))
+	}
+	if o.logger != nil {
+		middlewares = append(middlewares, LoggingMiddleware(o.logger))
+	}
+	return Chain(doer, middlewares...)
+}
+
+func (o *options) buildRequestClient(url string) (*RequestClient, error) {
+	if o.network != nil {
+		url = o.network.APIURL
+	}
+
+	r, err := NewRequestClientWithDoer(url, o.buildDoer())
+	if err != nil {
+		return nil, err
+	}
+
+	r.apiKey = o.apiKey
+	r.retry = o.retry
+	r.limiter = o.limiter
+	for module, limiter := range o.modLimiters {
+		r.SetModuleRateLimiter(module, limiter)
+	}
+	return r, nil
+}
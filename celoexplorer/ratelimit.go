@@ -0,0 +1,216 @@
+package celoexplorer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that can be shared by any number of goroutines and clients.
+// Every request to the explorer, including retries, takes one token.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	requests  int64
+	waited    int64
+	waiting   int64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+// Snapshot of how much a RateLimiter has been holding requests back.
+type RateLimiterStats struct {
+	// Requests that went through the limiter.
+	Requests int64
+	// Requests that had to wait for a token.
+	Waited int64
+	// Requests waiting for a token right now.
+	Waiting int64
+	// Sum and maximum of the time spent waiting.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// Create a limiter allowing requestsPerSecond on average with bursts of up to burst requests.
+// A non-positive rate means no limit.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Block until a token is available or ctx is done.
+// Returns immediately with an error if the wait would outlast the deadline of ctx.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.requests++
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	l.refill(now)
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && wait > 0 && now.Add(wait).After(deadline) {
+		l.tokens++
+		l.requests--
+		l.mu.Unlock()
+		return fmt.Errorf("celoexplorer: rate limiter wait of %v exceeds context deadline: %w", wait, context.DeadlineExceeded)
+	}
+
+	if wait == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	l.waiting++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		// hand the reserved token back to the other callers
+		l.tokens++
+		l.requests--
+		l.waiting--
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		l.mu.Lock()
+		l.waiting--
+		l.waited++
+		l.totalWait += wait
+		if wait > l.maxWait {
+			l.maxWait = wait
+		}
+		l.mu.Unlock()
+		return nil
+	}
+}
+
+// Add the tokens earned since the last call. Must hold mu.
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+
+	l.tokens += elapsed.Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// Give back a token taken by Wait for a request that was not sent after all.
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.requests--
+	if l.rate <= 0 {
+		return
+	}
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// Current wait statistics.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return RateLimiterStats{
+		Requests:  l.requests,
+		Waited:    l.waited,
+		Waiting:   l.waiting,
+		TotalWait: l.totalWait,
+		MaxWait:   l.maxWait,
+	}
+}
+
+// Set the limiter applied to every request made by this client. Nil removes the limit.
+func (r *RequestClient) SetRateLimiter(limiter *RateLimiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limiter = limiter
+}
+
+// Set an extra limiter for one module of the API, such as "account", "logs" or "contract".
+// Requests to that module wait for both this limiter and the one from SetRateLimiter. Nil removes the limit.
+func (r *RequestClient) SetModuleRateLimiter(module string, limiter *RateLimiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// copy so that requests reading the old map are not disturbed
+	limiters := make(map[string]*RateLimiter, len(r.moduleLimiters)+1)
+	for m, l := range r.moduleLimiters {
+		limiters[m] = l
+	}
+	if limiter == nil {
+		delete(limiters, module)
+	} else {
+		limiters[module] = limiter
+	}
+	r.moduleLimiters = limiters
+}
+
+// Wait for the limiters that apply to a request to module.
+func (r *RequestClient) waitRateLimit(ctx context.Context, module string) error {
+	r.mu.RLock()
+	global, moduleLimiter := r.limiter, r.moduleLimiters[module]
+	r.mu.RUnlock()
+
+	if global != nil {
+		if err := global.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	if moduleLimiter != nil {
+		if err := moduleLimiter.Wait(ctx); err != nil {
+			if global != nil {
+				// the request is not sent, so its global token goes to the other callers
+				global.release()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Set the limiter applied to every request made by this client. Nil removes the limit.
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.req.SetRateLimiter(limiter)
+}
+
+// Set an extra limiter for one module of the API, such as "account", "logs" or "contract".
+func (c *Client) SetModuleRateLimiter(module string, limiter *RateLimiter) {
+	c.req.SetModuleRateLimiter(module, limiter)
+}
@@ -0,0 +1,155 @@
+package celoexplorer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("burst took %v", elapsed)
+	}
+
+	stats := l.Stats()
+	if stats.Requests != 3 || stats.Waited != 0 {
+		t.Errorf("got %+v, want 3 requests without waiting", stats)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(20, 1)
+	l.Wait(context.Background())
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// one token every 50ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("second request waited only %v", elapsed)
+	}
+
+	stats := l.Stats()
+	if stats.Requests != 2 || stats.Waited != 1 || stats.MaxWait <= 0 || stats.Waiting != 0 {
+		t.Errorf("got %+v, want 2 requests, 1 of them waiting", stats)
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	l := NewRateLimiter(0, 1)
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := l.Stats(); stats.Requests != 100 || stats.Waited != 0 {
+		t.Errorf("got %+v", stats)
+	}
+}
+
+func TestRateLimiterDeadline(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	l.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the next token is 10s away, so the wait is refused at once
+	start := time.Now()
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("refusal took %v", elapsed)
+	}
+	if stats := l.Stats(); stats.Requests != 1 {
+		t.Errorf("got %d requests, want 1", stats.Requests)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.Wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(ctx)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if stats := l.Stats(); stats.Waiting != 1 {
+		t.Errorf("got %d waiting, want 1", stats.Waiting)
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if stats := l.Stats(); stats.Requests != 1 || stats.Waiting != 0 || stats.Waited != 0 {
+		t.Errorf("got %+v, want only the first request", stats)
+	}
+
+	// the cancelled request handed its token back, so the next one waits for one token, not two
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.01 || tokens > 0.1 {
+		t.Errorf("got %v tokens, want about 0", tokens)
+	}
+}
+
+func TestRateLimiterRelease(t *testing.T) {
+	l := NewRateLimiter(0.1, 2)
+	l.Wait(context.Background())
+	l.Wait(context.Background())
+	l.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("released token was not available: %v", err)
+	}
+	if stats := l.Stats(); stats.Requests != 2 {
+		t.Errorf("got %d requests, want 2", stats.Requests)
+	}
+
+	// never above the burst
+	l.release()
+	l.release()
+	l.release()
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens > 2 {
+		t.Errorf("got %v tokens, want at most the burst of 2", tokens)
+	}
+}
+
+func TestModuleLimiterGivesBackGlobalToken(t *testing.T) {
+	global := NewRateLimiter(0.1, 1)
+	r := &RequestClient{limiter: global, moduleLimiters: map[string]*RateLimiter{"logs": NewRateLimiter(0.1, 1)}}
+	r.moduleLimiters["logs"].Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := r.waitRateLimit(ctx, "logs"); err == nil {
+		t.Fatal("module limiter did not refuse")
+	}
+
+	// the global token is still there for another module
+	if err := r.waitRateLimit(ctx, "account"); err != nil {
+		t.Errorf("global token was not given back: %v", err)
+	}
+}
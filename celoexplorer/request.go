@@ -1,14 +1,16 @@
 package celoexplorer
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -45,81 +47,178 @@ const (
 )
 
 type RequestClient struct {
-	http *http.Client
-	base string
+	http   Doer
+	base   *url.URL
+	apiKey string
+	// guards retry, limiter and moduleLimiters, which can be changed while requests are running
+	mu             sync.RWMutex
+	retry          *RetryPolicy
+	limiter        *RateLimiter
+	moduleLimiters map[string]*RateLimiter
 }
 
-func NewRequestClientWithHttp(url string, http *http.Client) *RequestClient {
+// Create a client for the explorer api at baseUrl, such as BaseUrl. Fails if baseUrl is not an absolute http(s) url ending in /api.
+func NewRequestClientWithHttp(baseUrl string, http *http.Client) (*RequestClient, error) {
+	return NewRequestClientWithDoer(baseUrl, http)
+}
+
+// Create a client that sends its requests through doer, which may be a middleware chain built with Chain.
+func NewRequestClientWithDoer(baseUrl string, doer Doer) (*RequestClient, error) {
+	base, err := parseBaseUrl(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RequestClient{
-		http: http,
-		base: url,
+		http: doer,
+		base: base,
+	}, nil
+}
+
+func parseBaseUrl(baseUrl string) (*url.URL, error) {
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("celoexplorer: invalid base url %q: %w", baseUrl, err)
+	}
+
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("celoexplorer: invalid base url %q: scheme must be http or https", baseUrl)
+	case u.Host == "":
+		return nil, fmt.Errorf("celoexplorer: invalid base url %q: missing host", baseUrl)
+	case u.RawQuery != "" || u.Fragment != "":
+		return nil, fmt.Errorf("celoexplorer: invalid base url %q: must not have a query or fragment", baseUrl)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	if !strings.HasSuffix(u.Path, "/api") {
+		return nil, fmt.Errorf("celoexplorer: invalid base url %q: path must end in /api", baseUrl)
 	}
+	return u, nil
 }
 
-func add0x(s string) string {
-	var sb strings.Builder
-	sb.WriteString("0x")
-	sb.WriteString(s)
-	return sb.String()
+// Url of an endpoint, made of the base url and the fixed parameters of template such as module and action.
+// Placeholders like {addressHash} only document the endpoint and are dropped; the query builder sets the actual values.
+func (r *RequestClient) endpoint(template string) *url.URL {
+	values := make(url.Values)
+	for _, pair := range strings.Split(strings.TrimPrefix(template, "?"), "&") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 && !strings.HasPrefix(kv[1], "{") {
+			values.Set(kv[0], kv[1])
+		}
+	}
+
+	u := cloneUrl(r.base)
+	u.RawQuery = values.Encode()
+	return u
 }
 
-func buildUrl(ss ...string) *url.URL {
-	var sb strings.Builder
-	for _, s := range ss {
-		sb.WriteString(s)
+func cloneUrl(u *url.URL) *url.URL {
+	clone := *u
+	return &clone
+}
+
+// Send a GET request and read the whole body.
+func (r *RequestClient) get(ctx context.Context, u *url.URL) (*http.Response, []byte, error) {
+	if err := r.waitRateLimit(ctx, u.Query().Get("module")); err != nil {
+		return nil, nil, err
+	}
+
+	target := u
+	if r.apiKey != "" {
+		target = cloneUrl(u)
+		newQueryBuilder(target).set("apikey", r.apiKey)
 	}
 
-	u, err := url.Parse(sb.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
-	return u
-}
 
-func (r *RequestClient) jsonResponse(u *url.URL, respObject interface{}) error {
-	resp, err := r.http.Get(u.String())
+	resp, err := r.http.Do(req)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	return resp, body, nil
+}
 
-	var baseResp BaseResponse
-	json.Unmarshal(body, &baseResp)
+func (r *RequestClient) jsonResponse(ctx context.Context, u *url.URL, respObject interface{}) error {
+	return r.withRetry(ctx, func() error {
+		resp, body, err := r.get(ctx, u)
+		if err != nil {
+			return err
+		}
 
-	if baseResp.Status == "1" {
-		json.Unmarshal(baseResp.Result, respObject)
-	} else {
-		return errors.New(baseResp.Message)
+		var baseResp BaseResponse
+		if err := json.Unmarshal(body, &baseResp); err != nil {
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return newAPIError(u, resp, body).classify()
+			}
+			return newDecodeError(u, resp, body, err)
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 || baseResp.Status != "1" {
+			apiErr := newAPIError(u, resp, body)
+			apiErr.Status = baseResp.Status
+			apiErr.Message = baseResp.Message
+			return apiErr.classify()
+		}
+
+		if err := json.Unmarshal(baseResp.Result, respObject); err != nil {
+			return newDecodeError(u, resp, body, err)
+		}
+		return nil
+	})
+}
+
+// Same as jsonResponse, but a "no results" answer from a list endpoint leaves respObject untouched and is not an error.
+func (r *RequestClient) jsonListResponse(ctx context.Context, u *url.URL, respObject interface{}) error {
+	err := r.jsonResponse(ctx, u, respObject)
+	if isEmptyResult(err) {
+		return nil
 	}
-	return nil
+	return err
+}
+
+// Failure responses that carry an error message.
+type failureMessage interface {
+	message() string
 }
 
 // Use different json parser for different response code
 // return true if success
-func (r *RequestClient) jsonResponseDiff(u *url.URL, respSuccess, respFailure interface{}) (bool, error) {
-	resp, err := r.http.Get(u.String())
-	if err != nil {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return false, err
-	}
+func (r *RequestClient) jsonResponseDiff(ctx context.Context, u *url.URL, respSuccess, respFailure interface{}) (bool, error) {
+	var ok bool
+	err := r.withRetry(ctx, func() error {
+		resp, body, err := r.get(ctx, u)
+		if err != nil {
+			return err
+		}
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		json.Unmarshal(body, respSuccess)
-		return true, nil
-	}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if err := json.Unmarshal(body, respSuccess); err != nil {
+				return newDecodeError(u, resp, body, err)
+			}
+			ok = true
+			return nil
+		}
 
-	json.Unmarshal(body, respFailure)
-	return false, nil
+		apiErr := newAPIError(u, resp, body)
+		if err := json.Unmarshal(body, respFailure); err == nil {
+			if m, ok := respFailure.(failureMessage); ok {
+				apiErr.Message = m.message()
+			}
+		}
+		return apiErr.classify()
+	})
+	return ok, err
 }
 
 type queryBuilder struct {
@@ -145,23 +244,23 @@ func (qb *queryBuilder) setIfExist(key string, value *string) {
 	}
 }
 
-func (qb *queryBuilder) address(address string) {
-	qb.set("address", add0x(address))
+func (qb *queryBuilder) address(address Address) {
+	qb.set("address", address.Hex())
 }
 
-func (qb *queryBuilder) txHash(hash string) {
-	qb.set("txhash", add0x(hash))
+func (qb *queryBuilder) txHash(hash Hash) {
+	qb.set("txhash", hash.Hex())
 }
 
-func (qb *queryBuilder) contractAddress(address string) {
-	qb.set("contractaddress", add0x(address))
+func (qb *queryBuilder) contractAddress(address Address) {
+	qb.set("contractaddress", address.Hex())
 }
 
 
-func (qb *queryBuilder) addressMulti(address []string) {
+func (qb *queryBuilder) addressMulti(address []Address) {
 	formatAddress := make([]string, len(address))
 	for i, v := range address {
-		formatAddress[i] = add0x(v)
+		formatAddress[i] = v.Hex()
 	}
 	qb.set("address", strings.Join(formatAddress, ","))
 }
@@ -248,65 +347,25 @@ func (qb *queryBuilder) blockRangeAdv(block BlockRangeAdv) {
 	}
 }
 
+// Set topics and the operators between every pair of them.
 func (qb *queryBuilder) topics(topics Topics) {
-	// optional topic 1,2 and 3
-	qb.set("topic0", add0x(topics.Topic0))
-
-	// (1,0,0)
-	if topics.Topic1 != nil {
-		qb.set("topic1", add0x(*topics.Topic1))
-		qb.set("topic0_1_opr", add0x(string(*topics.Opr01)))
-
-		// (1,1,0)
-		if topics.Topic2 != nil {
-			qb.set("topic2", add0x(*topics.Topic2))
-			qb.set("topic0_2_opr", add0x(string(*topics.Opr02)))
-			qb.set("topic1_2_opr", add0x(string(*topics.Opr12)))
-			
-			// (1,1,1)
-			if topics.Topic3 != nil {
-				qb.set("topic3", add0x(*topics.Topic3))
-				qb.set("topic0_3_opr", add0x(string(*topics.Opr03)))
-				qb.set("topic1_3_opr", add0x(string(*topics.Opr13)))
-				qb.set("topic2_3_opr", add0x(string(*topics.Opr23)))
-			}
-		
-		// (1,0,0)
-		} else {
-			// (1,0,1)
-			if topics.Topic3 != nil {
-				qb.set("topic3", add0x(*topics.Topic3))
-				qb.set("topic0_3_opr", add0x(string(*topics.Opr03)))
-				qb.set("topic1_3_opr", add0x(string(*topics.Opr13)))
-			} 
+	present := topics.present()
+	for i, topic := range present {
+		if topic == nil {
+			continue
 		}
-	// (0,0,0)
-	} else {
-		// (0,1,0)
-		if topics.Topic2 != nil {
-			qb.set("topic2", add0x(*topics.Topic2))
-			qb.set("topic0_2_opr", add0x(string(*topics.Opr02)))
-
-			// (0,1,1)
-			if topics.Topic3 != nil {
-				qb.set("topic3", add0x(*topics.Topic3))
-				qb.set("topic0_3_opr", add0x(string(*topics.Opr03)))
-				qb.set("topic2_3_opr", add0x(string(*topics.Opr23)))
-			}	
-
-		// (0,0,0)
-		} else {
-			// (0,0,1)
-			if topics.Topic3 != nil {
-				qb.set("topic3", add0x(*topics.Topic3))
-				qb.set("topic0_3_opr", add0x(string(*topics.Opr03)))
-			} 
+		qb.set(fmt.Sprintf("topic%d", i), topic.Hex())
+
+		for j := i + 1; j < len(present); j++ {
+			if present[j] != nil {
+				qb.set(fmt.Sprintf("topic%d_%d_opr", i, j), string(*topics.operator(i, j)))
+			}
 		}
 	}
 }
 
 func (qb *queryBuilder) verify(contract ContractInfo) {
-	qb.set("addressHash", add0x(contract.AddressHash))
+	qb.set("addressHash", contract.AddressHash.Hex())
 	qb.set("name", contract.Name)
 	qb.set("compilerVersion", contract.CompilerVersion)
 
@@ -334,7 +393,9 @@ func (qb *queryBuilder) verify(contract ContractInfo) {
 		qb.set("optimizationRuns", strconv.Itoa(*contract.OptimizationRuns))
 	}
 
-	qb.setIfExist("proxyAddress", contract.ProxyAddress)
+	if contract.ProxyAddress != nil {
+		qb.set("proxyAddress", contract.ProxyAddress.Hex())
+	}
 	qb.setIfExist("library1Name", contract.Library1Name)
 	qb.setIfExist("library2Name", contract.Library2Name)
 	qb.setIfExist("library3Name", contract.Library3Name)
@@ -342,23 +403,23 @@ func (qb *queryBuilder) verify(contract ContractInfo) {
 	qb.setIfExist("library5Name", contract.Library5Name)
 
 	if contract.Library1Address != nil {
-		qb.set("library1Address", add0x(*contract.Library1Address))
+		qb.set("library1Address", contract.Library1Address.Hex())
 	}
 
 	if contract.Library2Address != nil {
-		qb.set("library2Address", add0x(*contract.Library2Address))
+		qb.set("library2Address", contract.Library2Address.Hex())
 	}
 
 	if contract.Library3Address != nil {
-		qb.set("library3Address", add0x(*contract.Library3Address))
+		qb.set("library3Address", contract.Library3Address.Hex())
 	}
 
 	if contract.Library4Address != nil {
-		qb.set("library4Address", add0x(*contract.Library4Address))
+		qb.set("library4Address", contract.Library4Address.Hex())
 	}
 
 	if contract.Library5Address != nil {
-		qb.set("library5Address", add0x(*contract.Library5Address))
+		qb.set("library5Address", contract.Library5Address.Hex())
 	}
 }
 
@@ -371,7 +432,7 @@ func (qb *queryBuilder) index(num *int) {
 
 type ContractInfo struct {
 	// I don't know how to align
-	AddressHash string
+	AddressHash Address
 	Name string
 	CompilerVersion string
 	Optimization bool
@@ -380,17 +441,17 @@ type ContractInfo struct {
 	AutodetectConstructorArguments *bool
 	EvmVersion *string
 	OptimizationRuns *int
-	ProxyAddress *string
+	ProxyAddress *Address
 	Library1Name *string
-	Library1Address *string
+	Library1Address *Address
 	Library2Name *string
-	Library2Address *string
+	Library2Address *Address
 	Library3Name *string
-	Library3Address *string
+	Library3Address *Address
 	Library4Name *string
-	Library4Address *string
+	Library4Address *Address
 	Library5Name *string
-	Library5Address *string
+	Library5Address *Address
 }
 
 type sortDirectionType string
@@ -414,10 +475,10 @@ var TopicOperator = struct {
 }
 
 type Topics struct {
-	Topic0 string
-	Topic1 *string
-	Topic2 *string
-	Topic3 *string
+	Topic0 Hash
+	Topic1 *Hash
+	Topic2 *Hash
+	Topic3 *Hash
 	Opr01  *topicOperatorType
 	Opr02  *topicOperatorType
 	Opr03  *topicOperatorType
@@ -426,6 +487,37 @@ type Topics struct {
 	Opr23  *topicOperatorType
 }
 
+// Topics that are set, by position.
+func (t Topics) present() [4]*Hash {
+	var topics [4]*Hash
+	if !t.Topic0.IsZero() {
+		topics[0] = &t.Topic0
+	}
+	topics[1] = t.Topic1
+	topics[2] = t.Topic2
+	topics[3] = t.Topic3
+	return topics
+}
+
+// Operator between topic i and topic j, i < j.
+func (t Topics) operator(i, j int) *topicOperatorType {
+	switch {
+	case i == 0 && j == 1:
+		return t.Opr01
+	case i == 0 && j == 2:
+		return t.Opr02
+	case i == 0 && j == 3:
+		return t.Opr03
+	case i == 1 && j == 2:
+		return t.Opr12
+	case i == 1 && j == 3:
+		return t.Opr13
+	case i == 2 && j == 3:
+		return t.Opr23
+	}
+	return nil
+}
+
 type BlockRangeAdv struct {
 	FromBlock *big.Int
 	ToBlock   *big.Int
@@ -475,64 +567,100 @@ var FilterContract = struct {
 
 // Mimics Ethereum JSON RPC's eth_getBalance.
 // Returns the wei balance (1 Celo = 10^18 wei) for an address as of the provided block (defaults to latest).
-func (r *RequestClient) EthGetBalance(address string, block *big.Int) (string, error) {
-	u := buildUrl(r.base, ethGetBalanceUrl)
+func (r *RequestClient) EthGetBalance(address Address, block *big.Int) (string, error) {
+	return r.EthGetBalanceCtx(context.Background(), address, block)
+}
+
+// EthGetBalanceCtx is EthGetBalance with a context.
+func (r *RequestClient) EthGetBalanceCtx(ctx context.Context, address Address, block *big.Int) (string, error) {
+	if err := validate("EthGetBalance", checkBlockNumber("block", block, false)); err != nil {
+		return "", err
+	}
+
+	u := r.endpoint(ethGetBalanceUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 	qb.block(block)
 
 	var ethResult EthResult
 	var ethError EthError
-	ok, err := r.jsonResponseDiff(u, ethResult, ethError)
-	if err != nil {
+	if _, err := r.jsonResponseDiff(ctx, u, &ethResult, &ethError); err != nil {
 		return "", err
 	}
 
-	if !ok {
-		return "", errors.New(ethError.Error)
-	}
-
 	return ethResult.Result, nil
 }
 
 // Get balance for address.
-func (r *RequestClient) Balance(address string) (Balance, error) {
-	u := buildUrl(r.base, balanceUrl)
+func (r *RequestClient) Balance(address Address) (Balance, error) {
+	return r.BalanceCtx(context.Background(), address)
+}
+
+// BalanceCtx is Balance with a context.
+func (r *RequestClient) BalanceCtx(ctx context.Context, address Address) (Balance, error) {
+	u := r.endpoint(balanceUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 
 	var balance Balance
-	err := r.jsonResponse(u, &balance)
+	err := r.jsonResponse(ctx, u, &balance)
 	return balance, err
 }
 
 // Get balance for multiple addresses.
 // If the balance hasn't been updated in a long time, we will double check with the node to fetch the absolute latest balance. This will not be reflected in the current request, but once it is updated, subsequent requests will show the updated balance. You can know that this is taking place via the `stale` attribute, which is set to `true` if a new balance is being fetched.
-func (r *RequestClient) BalanceMulti(address []string) ([]BalanceMulti, error) {
-	u := buildUrl(r.base, balanceMultiUrl)
+func (r *RequestClient) BalanceMulti(address []Address) ([]BalanceMulti, error) {
+	return r.BalanceMultiCtx(context.Background(), address)
+}
+
+// BalanceMultiCtx is BalanceMulti with a context.
+func (r *RequestClient) BalanceMultiCtx(ctx context.Context, address []Address) ([]BalanceMulti, error) {
+	if err := validate("BalanceMulti", checkAddressMulti("address", address)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(balanceMultiUrl)
 	qb := newQueryBuilder(u)
 	qb.addressMulti(address)
 
 	var balanceMulti []BalanceMulti
-	err := r.jsonResponse(u, &balanceMulti)
+	err := r.jsonResponse(ctx, u, &balanceMulti)
 	return balanceMulti, err
 }
 
 // Get pending transactions by address.
-func (r *RequestClient) PendingTxList(address string, page *PageRange) ([]PendingTxList, error) {
-	u := buildUrl(r.base, pendingTxListUrl)
+func (r *RequestClient) PendingTxList(address Address, page *PageRange) ([]PendingTxList, error) {
+	return r.PendingTxListCtx(context.Background(), address, page)
+}
+
+// PendingTxListCtx is PendingTxList with a context.
+func (r *RequestClient) PendingTxListCtx(ctx context.Context, address Address, page *PageRange) ([]PendingTxList, error) {
+	if err := validate("PendingTxList", checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(pendingTxListUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 	qb.pageRange(page)
 
-	var pendingtxlist []PendingTxList
-	err := r.jsonResponse(u, &pendingtxlist)
+	pendingtxlist := []PendingTxList{}
+	err := r.jsonListResponse(ctx, u, &pendingtxlist)
 	return pendingtxlist, err
 }
 
 // Get transactions sent by an address. Up to a maximum of 10,000 transactions.
-func (r *RequestClient) TxList(address string, sort *sortDirectionType, block *BlockRange, page *PageRange, filter *filterDirectionType, timeRange *TimeRange) ([]TxList, error) {
-	u := buildUrl(r.base, txListUrl)
+func (r *RequestClient) TxList(address Address, sort *sortDirectionType, block *BlockRange, page *PageRange, filter *filterDirectionType, timeRange *TimeRange) ([]TxList, error) {
+	return r.TxListCtx(context.Background(), address, sort, block, page, filter, timeRange)
+}
+
+// TxListCtx is TxList with a context.
+func (r *RequestClient) TxListCtx(ctx context.Context, address Address, sort *sortDirectionType, block *BlockRange, page *PageRange, filter *filterDirectionType, timeRange *TimeRange) ([]TxList, error) {
+	if err := validate("TxList", checkBlockRange(block), checkPageRange(page), checkTimeRange(timeRange)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(txListUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 	qb.sort(sort)
@@ -541,16 +669,28 @@ func (r *RequestClient) TxList(address string, sort *sortDirectionType, block *B
 	qb.filterByDirection(filter)
 	qb.timeRange(timeRange)
 
-	var txList []TxList
-	err := r.jsonResponse(u, &txList)
+	txList := []TxList{}
+	err := r.jsonListResponse(ctx, u, &txList)
 	return txList, err
 }
 
 // Get internal transactions by transaction or address hash. Up to a maximum of 10,000 internal transactions.
-func (r *RequestClient) TxListInternal(txhash string, address *string, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TxListInternal, error) {
-	u := buildUrl(r.base, txListInternalUrl)
+// A zero txhash leaves it out of the query. Prefer TxListInternalByHash and TxListInternalByAddress.
+func (r *RequestClient) TxListInternal(txhash Hash, address *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TxListInternal, error) {
+	return r.TxListInternalCtx(context.Background(), txhash, address, sort, block, page)
+}
+
+// TxListInternalCtx is TxListInternal with a context.
+func (r *RequestClient) TxListInternalCtx(ctx context.Context, txhash Hash, address *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TxListInternal, error) {
+	if err := validate("TxListInternal", checkHashOrAddress(txhash, address), checkBlockRange(block), checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(txListInternalUrl)
 	qb := newQueryBuilder(u)
-	qb.txHash(txhash)
+	if !txhash.IsZero() {
+		qb.txHash(txhash)
+	}
 	if address != nil {
 		qb.address(*address)
 	}
@@ -558,14 +698,47 @@ func (r *RequestClient) TxListInternal(txhash string, address *string, sort *sor
 	qb.blockRange(block)
 	qb.pageRange(page)
 	
-	var txListInternal []TxListInternal
-	err := r.jsonResponse(u, &txListInternal)
+	txListInternal := []TxListInternal{}
+	err := r.jsonListResponse(ctx, u, &txListInternal)
 	return txListInternal, err
 }
 
+// Get internal transactions of a transaction.
+func (r *RequestClient) TxListInternalByHash(txhash Hash, page *PageRange) ([]TxListInternal, error) {
+	return r.TxListInternalByHashCtx(context.Background(), txhash, page)
+}
+
+// TxListInternalByHashCtx is TxListInternalByHash with a context.
+func (r *RequestClient) TxListInternalByHashCtx(ctx context.Context, txhash Hash, page *PageRange) ([]TxListInternal, error) {
+	if err := validate("TxListInternalByHash", checkHash("txhash", txhash)); err != nil {
+		return nil, err
+	}
+
+	return r.TxListInternalCtx(ctx, txhash, nil, nil, nil, page)
+}
+
+// Get internal transactions to or from an address. Up to a maximum of 10,000 internal transactions.
+func (r *RequestClient) TxListInternalByAddress(address Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TxListInternal, error) {
+	return r.TxListInternalByAddressCtx(context.Background(), address, sort, block, page)
+}
+
+// TxListInternalByAddressCtx is TxListInternalByAddress with a context.
+func (r *RequestClient) TxListInternalByAddressCtx(ctx context.Context, address Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TxListInternal, error) {
+	return r.TxListInternalCtx(ctx, Hash{}, &address, sort, block, page)
+}
+
 // Get token transfer events by address. Up to a maximum of 10,000 token transfer events.
-func (r *RequestClient) TokenTx(address string, contractAddress *string, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TokenTx, error) {
-	u := buildUrl(r.base, tokenTxUrl)
+func (r *RequestClient) TokenTx(address Address, contractAddress *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TokenTx, error) {
+	return r.TokenTxCtx(context.Background(), address, contractAddress, sort, block, page)
+}
+
+// TokenTxCtx is TokenTx with a context.
+func (r *RequestClient) TokenTxCtx(ctx context.Context, address Address, contractAddress *Address, sort *sortDirectionType, block *BlockRange, page *PageRange) ([]TokenTx, error) {
+	if err := validate("TokenTx", checkBlockRange(block), checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(tokenTxUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 	if contractAddress != nil {
@@ -575,255 +748,404 @@ func (r *RequestClient) TokenTx(address string, contractAddress *string, sort *s
 	qb.blockRange(block)
 	qb.pageRange(page)
 
-	var tokenTx []TokenTx
-	err := r.jsonResponse(u, &tokenTx)
+	tokenTx := []TokenTx{}
+	err := r.jsonListResponse(ctx, u, &tokenTx)
 	return tokenTx, err
 }
 
 // Get token account balance for token contract address.
-func (r *RequestClient) TokenBalance(contractAddress, address string) (TokenBalance, error) {
-	u := buildUrl(r.base, tokenBalanceUrl)
+func (r *RequestClient) TokenBalance(contractAddress, address Address) (TokenBalance, error) {
+	return r.TokenBalanceCtx(context.Background(), contractAddress, address)
+}
+
+// TokenBalanceCtx is TokenBalance with a context.
+func (r *RequestClient) TokenBalanceCtx(ctx context.Context, contractAddress, address Address) (TokenBalance, error) {
+	u := r.endpoint(tokenBalanceUrl)
 	qb := newQueryBuilder(u)
 	qb.contractAddress(contractAddress)
 	qb.address(address)
 
 	var tokenBalance TokenBalance
-	err := r.jsonResponse(u, &tokenBalance)
+	err := r.jsonResponse(ctx, u, &tokenBalance)
 	return tokenBalance, err
 }
 
 // Get list of tokens owned by address.
-func (r *RequestClient) TokenList(address string) ([]TokenList, error) {
-	u := buildUrl(r.base, tokenListUrl)
+func (r *RequestClient) TokenList(address Address) ([]TokenList, error) {
+	return r.TokenListCtx(context.Background(), address)
+}
+
+// TokenListCtx is TokenList with a context.
+func (r *RequestClient) TokenListCtx(ctx context.Context, address Address) ([]TokenList, error) {
+	u := r.endpoint(tokenListUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 
-	var tokenList []TokenList
-	err := r.jsonResponse(u, &tokenList)
+	tokenList := []TokenList{}
+	err := r.jsonListResponse(ctx, u, &tokenList)
 	return tokenList, err
 }
 
 // Get list of blocks mined by address.
-func (r *RequestClient) GetMinedBlocks(address string, page *PageRange) ([]GetMinedBlocks, error) {
-	u := buildUrl(r.base, getMinedBlocksUrl)
+func (r *RequestClient) GetMinedBlocks(address Address, page *PageRange) ([]GetMinedBlocks, error) {
+	return r.GetMinedBlocksCtx(context.Background(), address, page)
+}
+
+// GetMinedBlocksCtx is GetMinedBlocks with a context.
+func (r *RequestClient) GetMinedBlocksCtx(ctx context.Context, address Address, page *PageRange) ([]GetMinedBlocks, error) {
+	if err := validate("GetMinedBlocks", checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(getMinedBlocksUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 	qb.pageRange(page)
 
-	var getMinedBlocks []GetMinedBlocks
-	err := r.jsonResponse(u, &getMinedBlocks)
+	getMinedBlocks := []GetMinedBlocks{}
+	err := r.jsonListResponse(ctx, u, &getMinedBlocks)
 	return getMinedBlocks, err
 }
 
 // Get a list of accounts and their balances, sorted ascending by the time they were first seen by the explorer.
 func (r *RequestClient) ListAccounts(page *PageRange) ([]ListAccounts, error) {
-	u := buildUrl(r.base, listAccountsUrl)
+	return r.ListAccountsCtx(context.Background(), page)
+}
+
+// ListAccountsCtx is ListAccounts with a context.
+func (r *RequestClient) ListAccountsCtx(ctx context.Context, page *PageRange) ([]ListAccounts, error) {
+	if err := validate("ListAccounts", checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(listAccountsUrl)
 	qb := newQueryBuilder(u)
 	qb.pageRange(page)
 
-	var listAccounts []ListAccounts
-	err := r.jsonResponse(u, &listAccounts)
+	listAccounts := []ListAccounts{}
+	err := r.jsonListResponse(ctx, u, &listAccounts)
 	return listAccounts, err
 }
 
 // Get event logs for an address and/or topics. Up to a maximum of 1,000 event logs.
-func (r *RequestClient) GetLogs(block BlockRangeAdv, contractAddress string, topics Topics) ([]GetLogs, error) {
-	u := buildUrl(r.base, getLogsUrl)
+func (r *RequestClient) GetLogs(block BlockRangeAdv, contractAddress Address, topics Topics) ([]GetLogs, error) {
+	return r.GetLogsCtx(context.Background(), block, contractAddress, topics)
+}
+
+// GetLogsCtx is GetLogs with a context.
+func (r *RequestClient) GetLogsCtx(ctx context.Context, block BlockRangeAdv, contractAddress Address, topics Topics) ([]GetLogs, error) {
+	if err := validate("GetLogs", checkBlockRangeAdv(block), checkTopics(contractAddress, topics)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(getLogsUrl)
 	qb := newQueryBuilder(u)
 	qb.blockRangeAdv(block)
-	qb.address(contractAddress)
+	if !contractAddress.IsZero() {
+		qb.address(contractAddress)
+	}
 	qb.topics(topics)
 
-	var getLogs []GetLogs
-	err := r.jsonResponse(u, &getLogs)
+	getLogs := []GetLogs{}
+	err := r.jsonListResponse(ctx, u, &getLogs)
 	return getLogs, err
 }
 
 // Get ERC-20 or ERC-721 token by contract address.
-func (r *RequestClient) GetToken(contractAddress string) (GetToken, error) {
-	u := buildUrl(r.base, getTokenUrl)
+func (r *RequestClient) GetToken(contractAddress Address) (GetToken, error) {
+	return r.GetTokenCtx(context.Background(), contractAddress)
+}
+
+// GetTokenCtx is GetToken with a context.
+func (r *RequestClient) GetTokenCtx(ctx context.Context, contractAddress Address) (GetToken, error) {
+	u := r.endpoint(getTokenUrl)
 	qb := newQueryBuilder(u)
 	qb.contractAddress(contractAddress)
 
 	var getToken GetToken
-	err := r.jsonResponse(u, &getToken)
+	err := r.jsonResponse(ctx, u, &getToken)
 	return getToken, err
 }
 
 // Get token holders by contract address.
-func (r *RequestClient) GetTokenHolders(contractAddress string, page *PageRange) ([]GetTokenHolders, error) {
-	u := buildUrl(r.base, getTokenHoldersUrl)
+func (r *RequestClient) GetTokenHolders(contractAddress Address, page *PageRange) ([]GetTokenHolders, error) {
+	return r.GetTokenHoldersCtx(context.Background(), contractAddress, page)
+}
+
+// GetTokenHoldersCtx is GetTokenHolders with a context.
+func (r *RequestClient) GetTokenHoldersCtx(ctx context.Context, contractAddress Address, page *PageRange) ([]GetTokenHolders, error) {
+	if err := validate("GetTokenHolders", checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(getTokenHoldersUrl)
 	qb := newQueryBuilder(u)
 	qb.contractAddress(contractAddress)
 	qb.pageRange(page)
 
-	var getTokenHolders []GetTokenHolders
-	err := r.jsonResponse(u, &getTokenHolders)
+	getTokenHolders := []GetTokenHolders{}
+	err := r.jsonListResponse(ctx, u, &getTokenHolders)
 	return getTokenHolders, err
 }
 
 // Get ERC-20 or ERC-721 token total supply by contract address.
-func (r *RequestClient) TokenSupply(contractAddress string) (TokenSupply, error) {
-	u := buildUrl(r.base, tokenSupplyUrl)
+func (r *RequestClient) TokenSupply(contractAddress Address) (TokenSupply, error) {
+	return r.TokenSupplyCtx(context.Background(), contractAddress)
+}
+
+// TokenSupplyCtx is TokenSupply with a context.
+func (r *RequestClient) TokenSupplyCtx(ctx context.Context, contractAddress Address) (TokenSupply, error) {
+	u := r.endpoint(tokenSupplyUrl)
 	qb := newQueryBuilder(u)
 	qb.contractAddress(contractAddress)
 
 	var tokenSupply TokenSupply
-	err := r.jsonResponse(u, &tokenSupply)
+	err := r.jsonResponse(ctx, u, &tokenSupply)
 	return tokenSupply, err
 }
 
 // Get total supply in Wei from exchange.
 func (r *RequestClient) EthSupplyExchange() (EthSupplyExchange, error) {
-	u := buildUrl(r.base, ethSupplyExchangeUrl)
+	return r.EthSupplyExchangeCtx(context.Background())
+}
+
+// EthSupplyExchangeCtx is EthSupplyExchange with a context.
+func (r *RequestClient) EthSupplyExchangeCtx(ctx context.Context) (EthSupplyExchange, error) {
+	u := r.endpoint(ethSupplyExchangeUrl)
 
 	var ethSupplyExchange EthSupplyExchange
-	err := r.jsonResponse(u, &ethSupplyExchange)
+	err := r.jsonResponse(ctx, u, &ethSupplyExchange)
 	return ethSupplyExchange, err
 }
 
 // Get total supply in Wei from DB.
 func (r *RequestClient) EthSupply() (EthSupply, error) {
-	u := buildUrl(r.base, ethSupplyUrl)
+	return r.EthSupplyCtx(context.Background())
+}
+
+// EthSupplyCtx is EthSupply with a context.
+func (r *RequestClient) EthSupplyCtx(ctx context.Context) (EthSupply, error) {
+	u := r.endpoint(ethSupplyUrl)
 
 	var ethSupply EthSupply
-	err := r.jsonResponse(u, &ethSupply)
+	err := r.jsonResponse(ctx, u, &ethSupply)
 	return ethSupply, err
 }
 
 // Get total coin supply from DB minus burnt number.
 func (r *RequestClient) CoinSupply() (CoinSupply, error) {
-	u := buildUrl(r.base, coinSupplyUrl)
+	return r.CoinSupplyCtx(context.Background())
+}
+
+// CoinSupplyCtx is CoinSupply with a context.
+func (r *RequestClient) CoinSupplyCtx(ctx context.Context) (CoinSupply, error) {
+	u := r.endpoint(coinSupplyUrl)
 
 	var coinSupply CoinSupply
-	err := r.jsonResponse(u, &coinSupply)
+	err := r.jsonResponse(ctx, u, &coinSupply)
 	return coinSupply, err
 }
 
 // Get latest price in USD and BTC.
 func (r *RequestClient) EthPrice() (EthPrice, error) {
-	u := buildUrl(r.base, ethPriceUrl)
+	return r.EthPriceCtx(context.Background())
+}
+
+// EthPriceCtx is EthPrice with a context.
+func (r *RequestClient) EthPriceCtx(ctx context.Context) (EthPrice, error) {
+	u := r.endpoint(ethPriceUrl)
 
 	var ethPrice EthPrice
-	err := r.jsonResponse(u, &ethPrice)
+	err := r.jsonResponse(ctx, u, &ethPrice)
 	return ethPrice, err
 }
 
 // Get estimated total number of transactions.
 func (r *RequestClient) TotalTransactions() (TotalTransactions, error) {
-	u := buildUrl(r.base, totalTransactionsUrl)
+	return r.TotalTransactionsCtx(context.Background())
+}
+
+// TotalTransactionsCtx is TotalTransactions with a context.
+func (r *RequestClient) TotalTransactionsCtx(ctx context.Context) (TotalTransactions, error) {
+	u := r.endpoint(totalTransactionsUrl)
 
 	var totalTransactions TotalTransactions
-	err := r.jsonResponse(u, &totalTransactions)
+	err := r.jsonResponse(ctx, u, &totalTransactions)
 	return totalTransactions, err
 }
 
 // Get block reward by block number.
 func (r *RequestClient) GetBlockReward(blockNumber *big.Int) (GetBlockReward, error) {
-	u := buildUrl(r.base, getBlockRewardUrl)
+	return r.GetBlockRewardCtx(context.Background(), blockNumber)
+}
+
+// GetBlockRewardCtx is GetBlockReward with a context.
+func (r *RequestClient) GetBlockRewardCtx(ctx context.Context, blockNumber *big.Int) (GetBlockReward, error) {
+	if err := validate("GetBlockReward", checkBlockNumber("blockNumber", blockNumber, true)); err != nil {
+		return GetBlockReward{}, err
+	}
+
+	u := r.endpoint(getBlockRewardUrl)
 	qb := newQueryBuilder(u)
 	qb.blockNo(blockNumber)
 
 	var getBlockReward GetBlockReward
-	err := r.jsonResponse(u, &getBlockReward)
+	err := r.jsonResponse(ctx, u, &getBlockReward)
 	return getBlockReward, err
 }
 
 // Mimics Ethereum JSON RPC's eth_blockNumber. Returns the lastest block number
 func (r *RequestClient) EthBlockNumber() (string, error) {
-	u := buildUrl(r.base, ethBlockNumberUrl)
+	return r.EthBlockNumberCtx(context.Background())
+}
+
+// EthBlockNumberCtx is EthBlockNumber with a context.
+func (r *RequestClient) EthBlockNumberCtx(ctx context.Context) (string, error) {
+	u := r.endpoint(ethBlockNumberUrl)
 
 	var ethResult EthResult
 	var ethError EthError
-	ok, err := r.jsonResponseDiff(u, ethResult, ethError)
-	if err != nil {
+	if _, err := r.jsonResponseDiff(ctx, u, &ethResult, &ethError); err != nil {
 		return "", err
 	}
 
-	if !ok {
-		return "", errors.New(ethError.Error)
-	}
-
 	return ethResult.Result, nil
 }
 
 // Get a list of contracts, sorted ascending by the time they were first seen by the explorer. If you provide the filters `not_decompiled`(`4`) or `not_verified(4)` the results will not be sorted for performance reasons.
 func (r *RequestClient) ListContracts(page *PageRange, filter *filterContractType, notVersion *string) ([]ListContracts, error) {
-	u := buildUrl(r.base, listContractsUrl)
+	return r.ListContractsCtx(context.Background(), page, filter, notVersion)
+}
+
+// ListContractsCtx is ListContracts with a context.
+func (r *RequestClient) ListContractsCtx(ctx context.Context, page *PageRange, filter *filterContractType, notVersion *string) ([]ListContracts, error) {
+	if err := validate("ListContracts", checkPageRange(page)); err != nil {
+		return nil, err
+	}
+
+	u := r.endpoint(listContractsUrl)
 	qb := newQueryBuilder(u)
 	qb.pageRange(page)
 	qb.filterContract(filter)
 	qb.notDecompiledWithVersion(notVersion)
 
-	var listContracts []ListContracts
-	err := r.jsonResponse(u, &listContracts)
+	listContracts := []ListContracts{}
+	err := r.jsonListResponse(ctx, u, &listContracts)
 	return listContracts, err
 }
 
 // Get ABI for verified contract. 
-func (r *RequestClient) GetAbi(address string) (GetAbi, error) {
-	u := buildUrl(r.base, getAbiUrl)
+func (r *RequestClient) GetAbi(address Address) (GetAbi, error) {
+	return r.GetAbiCtx(context.Background(), address)
+}
+
+// GetAbiCtx is GetAbi with a context.
+func (r *RequestClient) GetAbiCtx(ctx context.Context, address Address) (GetAbi, error) {
+	u := r.endpoint(getAbiUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 
 	var getAbi GetAbi
-	err := r.jsonResponse(u, &getAbi)
+	err := r.jsonResponse(ctx, u, &getAbi)
 	return getAbi, err
 }
 
 // Get contract source code for verified contract.
-func (r *RequestClient) GetSourceCode(address string, ignoreProxy *bool) (GetSourceCode, error) {
-	u := buildUrl(r.base, getSourceCodeUrl)
+func (r *RequestClient) GetSourceCode(address Address, ignoreProxy *bool) (GetSourceCode, error) {
+	return r.GetSourceCodeCtx(context.Background(), address, ignoreProxy)
+}
+
+// GetSourceCodeCtx is GetSourceCode with a context.
+func (r *RequestClient) GetSourceCodeCtx(ctx context.Context, address Address, ignoreProxy *bool) (GetSourceCode, error) {
+	u := r.endpoint(getSourceCodeUrl)
 	qb := newQueryBuilder(u)
 	qb.address(address)
 	qb.ignoreProxy(ignoreProxy)
 
 	var getSourceCode GetSourceCode
-	err := r.jsonResponse(u, &getSourceCode)
+	err := r.jsonResponse(ctx, u, &getSourceCode)
 	return getSourceCode, err
 }
 
 // Verify a contract with its source code and contract creation information.
 func (r *RequestClient) Verify(contract ContractInfo) (Verify, error) {
-	u := buildUrl(r.base, verifyUrl)
+	return r.VerifyCtx(context.Background(), contract)
+}
+
+// VerifyCtx is Verify with a context.
+// The submission is sent once whatever the retry policy, since sending it again may start a second verification.
+func (r *RequestClient) VerifyCtx(ctx context.Context, contract ContractInfo) (Verify, error) {
+	if err := validate("Verify", checkContractInfo(contract)); err != nil {
+		return Verify{}, err
+	}
+	ctx = ContextWithRetryPolicy(ctx, NoRetry)
+
+	u := r.endpoint(verifyUrl)
 	qb := newQueryBuilder(u)
 	qb.verify(contract)
 
 	var verify Verify
-	err := r.jsonResponse(u, &verify)
+	err := r.jsonResponse(ctx, u, &verify)
 	return verify, err
 }
 
 // Get transaction info.
-func (r *RequestClient) GetTxInfo(txhash string, index *int) (GetTxInfo, error) {
-	u := buildUrl(r.base, getTxInfoUrl)
+func (r *RequestClient) GetTxInfo(txhash Hash, index *int) (GetTxInfo, error) {
+	return r.GetTxInfoCtx(context.Background(), txhash, index)
+}
+
+// GetTxInfoCtx is GetTxInfo with a context.
+func (r *RequestClient) GetTxInfoCtx(ctx context.Context, txhash Hash, index *int) (GetTxInfo, error) {
+	if err := validate("GetTxInfo", checkHash("txhash", txhash), checkIndex(index)); err != nil {
+		return GetTxInfo{}, err
+	}
+
+	u := r.endpoint(getTxInfoUrl)
 	qb := newQueryBuilder(u)
 	qb.txHash(txhash)
 	qb.index(index)
 
 	var getTxInfo GetTxInfo
-	err := r.jsonResponse(u, &getTxInfo)
+	err := r.jsonResponse(ctx, u, &getTxInfo)
 	return getTxInfo, err
 }
 
 // Get transaction receipt status.
-func (r *RequestClient) GetTxReceiptStatus(txhash string) (GetTxReceiptStatus, error) {
-	u := buildUrl(r.base, getTxReceiptStatusUrl)
+func (r *RequestClient) GetTxReceiptStatus(txhash Hash) (GetTxReceiptStatus, error) {
+	return r.GetTxReceiptStatusCtx(context.Background(), txhash)
+}
+
+// GetTxReceiptStatusCtx is GetTxReceiptStatus with a context.
+func (r *RequestClient) GetTxReceiptStatusCtx(ctx context.Context, txhash Hash) (GetTxReceiptStatus, error) {
+	if err := validate("GetTxReceiptStatus", checkHash("txhash", txhash)); err != nil {
+		return GetTxReceiptStatus{}, err
+	}
+
+	u := r.endpoint(getTxReceiptStatusUrl)
 	qb := newQueryBuilder(u)
 	qb.txHash(txhash)
 
 	var getTxReceiptStatus GetTxReceiptStatus
-	err := r.jsonResponse(u, &getTxReceiptStatus)
+	err := r.jsonResponse(ctx, u, &getTxReceiptStatus)
 	return getTxReceiptStatus, err
 }
 
 // Get error status and error message.
-func (r *RequestClient) GetStatus(txhash string) (GetStatus, error) {
-	u := buildUrl(r.base, getStatusUrl)
+func (r *RequestClient) GetStatus(txhash Hash) (GetStatus, error) {
+	return r.GetStatusCtx(context.Background(), txhash)
+}
+
+// GetStatusCtx is GetStatus with a context.
+func (r *RequestClient) GetStatusCtx(ctx context.Context, txhash Hash) (GetStatus, error) {
+	if err := validate("GetStatus", checkHash("txhash", txhash)); err != nil {
+		return GetStatus{}, err
+	}
+
+	u := r.endpoint(getStatusUrl)
 	qb := newQueryBuilder(u)
 	qb.txHash(txhash)
 
 	var getStatus GetStatus
-	err := r.jsonResponse(u, &getStatus)
+	err := r.jsonResponse(ctx, u, &getStatus)
 	return getStatus, err
 }
\ No newline at end of file
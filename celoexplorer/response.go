@@ -21,6 +21,10 @@ type EthError struct {
 	Error string `json:"error"`
 }
 
+func (e EthError) message() string {
+	return e.Error
+}
+
 type Balance string
 
 type BalanceMulti struct {
@@ -69,6 +73,7 @@ type TxList struct {
 
 type TxListInternal struct {
 	Blocknumber     string `json:"blockNumber"`
+	Calltype        string `json:"callType"`
 	Contractaddress string `json:"contractAddress"`
 	Errcode         string `json:"errCode"`
 	From            string `json:"from"`
@@ -178,16 +183,21 @@ type EthPrice struct {
 type TotalTransactions string
 
 type GetBlockReward struct {
-	Blockminer           string      `json:"blockMiner"`
-	Blocknumber          string      `json:"blockNumber"`
-	Blockreward          string      `json:"blockReward"`
-	Timestamp            string      `json:"timeStamp"`
-	Uncleinclusionreward interface{} `json:"uncleInclusionReward"`
-	Uncles               interface{} `json:"uncles"`
+	Blockminer           string `json:"blockMiner"`
+	Blocknumber          string `json:"blockNumber"`
+	Blockreward          string `json:"blockReward"`
+	Timestamp            string `json:"timeStamp"`
+	Uncleinclusionreward string `json:"uncleInclusionReward"`
+	Uncles               []struct {
+		Miner         string `json:"miner"`
+		UnclePosition string `json:"unclePosition"`
+		Blockreward   string `json:"blockreward"`
+	} `json:"uncles"`
 }
 
 type ListContracts struct {
 	Abi              string `json:"ABI"`
+	Address          string `json:"Address"`
 	Compilerversion  string `json:"CompilerVersion"`
 	Contractname     string `json:"ContractName"`
 	Optimizationused string `json:"OptimizationUsed"`
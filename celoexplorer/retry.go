@@ -0,0 +1,151 @@
+package celoexplorer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy decides whether and when a failed request is sent again.
+// It applies to every endpoint of RequestClient, and so to Client as well.
+type RetryPolicy struct {
+	// Total number of attempts, including the first one. A value of 1 or less disables retrying.
+	MaxAttempts int
+	// Delay before the first retry.
+	InitialBackoff time.Duration
+	// Upper bound for the delay between two attempts, not counting Retry-After.
+	MaxBackoff time.Duration
+	// Factor the delay grows by after every attempt. Values below 1 are treated as 1.
+	Multiplier float64
+	// Fraction of the delay, between 0 and 1, that is randomized to spread out concurrent callers.
+	Jitter float64
+	// HTTP status codes that are retried.
+	RetryableStatus []int
+	// Wait for the duration in the Retry-After header instead of the computed delay when it is longer.
+	HonorRetryAfter bool
+	// Overrides the default decision of which errors are retryable when set.
+	// The default retries rate limits, 5xx responses, the status codes in RetryableStatus and network errors.
+	Retryable func(err error) bool
+}
+
+// Suggested retry policy for read-only use. Clients do not retry unless given a policy, e.g. with WithRetryPolicy(DefaultRetryPolicy).
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     4,
+	InitialBackoff:  500 * time.Millisecond,
+	MaxBackoff:      10 * time.Second,
+	Multiplier:      2,
+	Jitter:          0.2,
+	RetryableStatus: []int{429, 500, 502, 503, 504},
+	HonorRetryAfter: true,
+}
+
+// Retry policy that sends every request exactly once. Used when none is configured.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+type retryPolicyKey struct{}
+
+// ContextWithRetryPolicy returns a context that makes calls using it follow policy instead of the client's retry policy.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// Set the retry policy of all requests made by this client.
+func (r *RequestClient) SetRetryPolicy(policy RetryPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retry = &policy
+}
+
+// Policy that applies to a call with this context.
+func (r *RequestClient) retryPolicy(ctx context.Context) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return policy
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.retry != nil {
+		return *r.retry
+	}
+	return NoRetry
+}
+
+// Run attempt until it succeeds, fails with an error that is not retryable, or runs out of attempts.
+func (r *RequestClient) withRetry(ctx context.Context, attempt func() error) error {
+	policy := r.retryPolicy(ctx)
+
+	var err error
+	for n := 1; ; n++ {
+		err = attempt()
+		if err == nil || n >= policy.MaxAttempts || ctx.Err() != nil || !policy.retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(policy.delay(n, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// transport level failure such as a reset connection
+		return true
+	}
+
+	if errors.Is(apiErr, ErrRateLimited) || errors.Is(apiErr, ErrServer) {
+		return true
+	}
+	for _, code := range p.RetryableStatus {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Delay before attempt n+1.
+func (p RetryPolicy) delay(n int, err error) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(n-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d -= d * jitter * rand.Float64()
+	}
+
+	delay := time.Duration(d)
+	if p.HonorRetryAfter {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+	}
+	return delay
+}
+
+// Set the retry policy of all requests made by this client.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.req.SetRetryPolicy(policy)
+}
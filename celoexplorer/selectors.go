@@ -0,0 +1,189 @@
+package celoexplorer
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Signatures of common token and Celo core contract methods, known without an ABI.
+var knownSignatures = []string{
+	// ERC-20, and the Celo stable tokens
+	"transfer(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"approve(address,uint256)",
+	"increaseAllowance(address,uint256)",
+	"decreaseAllowance(address,uint256)",
+	"transferWithComment(address,uint256,string)",
+	"mint(address,uint256)",
+	"burn(uint256)",
+	// ERC-721
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	"setApprovalForAll(address,bool)",
+	// wrapped tokens
+	"deposit()",
+	"withdraw(uint256)",
+	// Accounts
+	"createAccount()",
+	"setName(string)",
+	"setAccountDataEncryptionKey(bytes)",
+	"setWalletAddress(address,uint8,bytes32,bytes32)",
+	"setMetadataURL(string)",
+	"authorizeVoteSigner(address,uint8,bytes32,bytes32)",
+	"authorizeValidatorSigner(address,uint8,bytes32,bytes32)",
+	"authorizeAttestationSigner(address,uint8,bytes32,bytes32)",
+	// LockedGold
+	"lock()",
+	"unlock(uint256)",
+	"relock(uint256,uint256)",
+	// Election
+	"vote(address,uint256,address,address)",
+	"activate(address)",
+	"activateForAccount(address,address)",
+	"revokePending(address,uint256,address,address,uint256)",
+	"revokeActive(address,uint256,address,address,uint256)",
+	"revokeAllActive(address,address,address,uint256)",
+	// Exchange
+	"sell(uint256,uint256,bool)",
+	"buy(uint256,uint256,bool)",
+	"exchange(uint256,uint256,bool)",
+	// Governance
+	"upvote(uint256,uint256,uint256)",
+	"approve(uint256,uint256)",
+	"execute(uint256,uint256)",
+	// Attestations
+	"request(bytes32,uint256,address)",
+	"selectIssuers(bytes32)",
+	"complete(bytes32,uint8,bytes32,bytes32)",
+	// Uniswap style routers
+	"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+	"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)",
+	"addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)",
+	"removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)",
+	// batching
+	"multicall(bytes[])",
+	"aggregate((address,bytes)[])",
+}
+
+// Methods by selector, filled from knownSignatures and RegisterSignatures.
+var selectorDB = struct {
+	sync.RWMutex
+	methods map[[4]byte]Method
+}{methods: make(map[[4]byte]Method)}
+
+func init() {
+	if err := RegisterSignatures(knownSignatures...); err != nil {
+		panic(err)
+	}
+}
+
+// Add method signatures such as "transfer(address,uint256)" to the selector database DecodeInput falls back on.
+// Tuples are written as their component types in parentheses. A signature replaces any earlier one with the same selector.
+func RegisterSignatures(signatures ...string) error {
+	methods := make([]Method, len(signatures))
+	for i, s := range signatures {
+		name, inputs, err := parseSignature(s)
+		if err != nil {
+			return err
+		}
+		if _, err := newABITypes(inputs); err != nil {
+			return fmt.Errorf("celoexplorer: invalid signature %q: %v", s, err)
+		}
+
+		signature := Signature(name, inputs)
+		methods[i] = Method{
+			Name:      name,
+			Signature: signature,
+			Selector:  selector(signature),
+			Inputs:    inputs,
+		}
+	}
+
+	selectorDB.Lock()
+	defer selectorDB.Unlock()
+	for _, m := range methods {
+		selectorDB.methods[m.Selector] = m
+	}
+	return nil
+}
+
+func lookupSelector(selector [4]byte) (Method, bool) {
+	selectorDB.RLock()
+	defer selectorDB.RUnlock()
+	m, ok := selectorDB.methods[selector]
+	return m, ok
+}
+
+// Name and unnamed inputs of a signature such as "aggregate((address,bytes)[])".
+func parseSignature(s string) (string, []ABIArgument, error) {
+	open := strings.Index(s, "(")
+	if open < 1 || !strings.HasSuffix(s, ")") {
+		return "", nil, fmt.Errorf("celoexplorer: invalid signature %q", s)
+	}
+
+	inputs, err := parseTypeList(s[open+1 : len(s)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("celoexplorer: invalid signature %q: %v", s, err)
+	}
+	return s[:open], inputs, nil
+}
+
+// Comma separated types, where a type in parentheses is a tuple of the types inside.
+func parseTypeList(s string) ([]ABIArgument, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var args []ABIArgument
+	var depth, start int
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			switch s[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				if depth < 0 {
+					return nil, fmt.Errorf("unbalanced parentheses")
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if depth != 0 {
+			return nil, fmt.Errorf("unbalanced parentheses")
+		}
+
+		arg, err := parseSignatureType(strings.TrimSpace(s[start:i]))
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		start = i + 1
+	}
+	return args, nil
+}
+
+func parseSignatureType(s string) (ABIArgument, error) {
+	if s == "" {
+		return ABIArgument{}, fmt.Errorf("empty type")
+	}
+	if !strings.HasPrefix(s, "(") {
+		return ABIArgument{Type: s}, nil
+	}
+
+	// the closing parenthesis is followed by the array suffix, if any
+	end := strings.LastIndex(s, ")")
+	components, err := parseTypeList(s[1:end])
+	if err != nil {
+		return ABIArgument{}, err
+	}
+	return ABIArgument{Type: "tuple" + s[end+1:], Components: components}, nil
+}
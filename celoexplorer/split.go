@@ -0,0 +1,457 @@
+package celoexplorer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Most results txlist and tokentx return for one query.
+const resultCap = 10000
+
+// Returned when a single block or second holds more results than the explorer returns for one query, so the range cannot be split any further.
+var ErrResultCap = errors.New("celoexplorer: too many results in a range that cannot be split")
+
+// Fetch [from, to]. A window that comes back full is split in half and each half is fetched again, recursively.
+// fetch reports whether the window was full, and must only keep its results when it was not.
+func bisectRange(ctx context.Context, from, to int64, fetch func(ctx context.Context, from, to int64) (bool, error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	full, err := fetch(ctx, from, to)
+	if err != nil || !full {
+		return err
+	}
+
+	if from >= to {
+		return fmt.Errorf("%w: %d", ErrResultCap, from)
+	}
+
+	mid := from + (to-from)/2
+	if err := bisectRange(ctx, from, mid, fetch); err != nil {
+		return err
+	}
+	return bisectRange(ctx, mid+1, to, fetch)
+}
+
+// Concrete bounds of block, resolving open ends to the genesis block and the latest block.
+func (c *Client) blockBounds(ctx context.Context, block *BlockRange) (int64, int64, error) {
+	var from, to int64
+	if block != nil && block.StartBlock != nil {
+		from = block.StartBlock.Int64()
+	}
+
+	if block != nil && block.EndBlock != nil {
+		to = block.EndBlock.Int64()
+	} else {
+		latest, err := c.latestBlock(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		to = latest
+	}
+	return from, to, nil
+}
+
+// Current head block. Ranges cannot be split without it, so it is decoded strictly even when decoding is lenient.
+func (c *Client) latestBlock(ctx context.Context) (int64, error) {
+	number, err := c.req.EthBlockNumberCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	d := c.decoder("EthBlockNumber")
+	d.strict = true
+	n := d.bigInt("result", number, 16)
+	if d.err != nil {
+		return 0, d.err
+	}
+	return n.Int64(), nil
+}
+
+func blockRangeOf(from, to int64) *BlockRange {
+	return &BlockRange{
+		StartBlock: big.NewInt(from),
+		EndBlock:   big.NewInt(to),
+	}
+}
+
+// Get every transaction of an address within block, splitting the range as often as needed to get past the 10,000 results cap.
+// A nil block, or nil bounds, covers the whole chain. Results are ordered by block number and transaction index, oldest first.
+func (c *Client) TxListAll(address Address, block *BlockRange, filter *filterDirectionType) ([]Transaction, error) {
+	return c.TxListAllCtx(context.Background(), address, block, filter)
+}
+
+// TxListAllCtx is TxListAll with a context.
+func (c *Client) TxListAllCtx(ctx context.Context, address Address, block *BlockRange, filter *filterDirectionType) ([]Transaction, error) {
+	from, to, err := c.blockBounds(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Transaction
+	err = bisectRange(ctx, from, to, func(ctx context.Context, from, to int64) (bool, error) {
+		txs, err := c.TxListCtx(ctx, address, &SortDirection.Asc, blockRangeOf(from, to), &PageRange{Page: 1, Offset: resultCap}, filter, nil)
+		if err != nil || len(txs) >= resultCap {
+			return true, err
+		}
+		all = append(all, txs...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortTransactions(all), nil
+}
+
+// Same as TxListAll, but splits a time range instead of a block range.
+func (c *Client) TxListAllByTime(address Address, timeRange TimeRange, filter *filterDirectionType) ([]Transaction, error) {
+	return c.TxListAllByTimeCtx(context.Background(), address, timeRange, filter)
+}
+
+// TxListAllByTimeCtx is TxListAllByTime with a context.
+func (c *Client) TxListAllByTimeCtx(ctx context.Context, address Address, timeRange TimeRange, filter *filterDirectionType) ([]Transaction, error) {
+	var all []Transaction
+	err := bisectRange(ctx, timeRange.Start.Unix(), timeRange.End.Unix(), func(ctx context.Context, from, to int64) (bool, error) {
+		window := &TimeRange{Start: time.Unix(from, 0), End: time.Unix(to, 0)}
+		txs, err := c.TxListCtx(ctx, address, &SortDirection.Asc, nil, &PageRange{Page: 1, Offset: resultCap}, filter, window)
+		if err != nil || len(txs) >= resultCap {
+			return true, err
+		}
+		all = append(all, txs...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortTransactions(all), nil
+}
+
+// Order by block and index, dropping any transaction the explorer listed twice.
+// Windows never overlap, so duplicates can only come from within one window.
+func sortTransactions(txs []Transaction) []Transaction {
+	seen := make(map[Hash]bool, len(txs))
+	unique := make([]Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if seen[tx.Hash] {
+			continue
+		}
+		seen[tx.Hash] = true
+		unique = append(unique, tx)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		if c := compareBigInt(unique[i].BlockNumber, unique[j].BlockNumber); c != 0 {
+			return c < 0
+		}
+		return unique[i].TransactionIndex < unique[j].TransactionIndex
+	})
+	return unique
+}
+
+// Get every token transfer of an address within block, splitting the range as often as needed to get past the 10,000 results cap.
+// A nil block, or nil bounds, covers the whole chain. Results are ordered by block number, transaction index and log index, oldest first.
+func (c *Client) TokenTxAll(address Address, contractAddress *Address, block *BlockRange) ([]TokenTransfer, error) {
+	return c.TokenTxAllCtx(context.Background(), address, contractAddress, block)
+}
+
+// TokenTxAllCtx is TokenTxAll with a context.
+func (c *Client) TokenTxAllCtx(ctx context.Context, address Address, contractAddress *Address, block *BlockRange) ([]TokenTransfer, error) {
+	from, to, err := c.blockBounds(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []TokenTransfer
+	err = bisectRange(ctx, from, to, func(ctx context.Context, from, to int64) (bool, error) {
+		transfers, err := c.TokenTxCtx(ctx, address, contractAddress, &SortDirection.Asc, blockRangeOf(from, to), &PageRange{Page: 1, Offset: resultCap})
+		if err != nil || len(transfers) >= resultCap {
+			return true, err
+		}
+		all = append(all, transfers...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortTokenTransfers(all), nil
+}
+
+func sortTokenTransfers(transfers []TokenTransfer) []TokenTransfer {
+	seen := make(map[logKey]bool, len(transfers))
+	unique := make([]TokenTransfer, 0, len(transfers))
+	for _, t := range transfers {
+		key := logKey{t.Hash, t.LogIndex}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, t)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		if c := compareBigInt(unique[i].BlockNumber, unique[j].BlockNumber); c != 0 {
+			return c < 0
+		}
+		if unique[i].TransactionIndex != unique[j].TransactionIndex {
+			return unique[i].TransactionIndex < unique[j].TransactionIndex
+		}
+		return unique[i].LogIndex < unique[j].LogIndex
+	})
+	return unique
+}
+
+// Get every internal transaction to or from an address within block, splitting the range as often as needed to get past the 10,000 results cap.
+// A nil block, or nil bounds, covers the whole chain. Results are ordered by block number and trace index, oldest first.
+func (c *Client) InternalTransactionsAll(address Address, block *BlockRange) ([]InternalTransaction, error) {
+	return c.InternalTransactionsAllCtx(context.Background(), address, block)
+}
+
+// InternalTransactionsAllCtx is InternalTransactionsAll with a context.
+func (c *Client) InternalTransactionsAllCtx(ctx context.Context, address Address, block *BlockRange) ([]InternalTransaction, error) {
+	from, to, err := c.blockBounds(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []InternalTransaction
+	err = bisectRange(ctx, from, to, func(ctx context.Context, from, to int64) (bool, error) {
+		internal, err := c.InternalTransactionsByAddressCtx(ctx, address, &SortDirection.Asc, blockRangeOf(from, to), &PageRange{Page: 1, Offset: resultCap})
+		if err != nil || len(internal) >= resultCap {
+			return true, err
+		}
+		all = append(all, internal...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortInternalTransactions(all), nil
+}
+
+func sortInternalTransactions(internal []InternalTransaction) []InternalTransaction {
+	seen := make(map[logKey]bool, len(internal))
+	unique := make([]InternalTransaction, 0, len(internal))
+	for _, t := range internal {
+		key := logKey{t.TransactionHash, t.Index}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, t)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		if c := compareBigInt(unique[i].BlockNumber, unique[j].BlockNumber); c != 0 {
+			return c < 0
+		}
+		if unique[i].TransactionHash != unique[j].TransactionHash {
+			// keep the frames of one transaction together
+			return bytes.Compare(unique[i].TransactionHash[:], unique[j].TransactionHash[:]) < 0
+		}
+		return unique[i].Index < unique[j].Index
+	})
+	return unique
+}
+
+// Identifies a log, or an internal transaction, within the chain.
+type logKey struct {
+	txHash Hash
+	index  int
+}
+
+// Compare two numbers that may be nil, nil being the smallest.
+func compareBigInt(a, b *big.Int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Cmp(b)
+}
+
+// Most event logs getLogs returns for one query.
+const logCap = 1000
+
+// Block window GetLogsIterator starts with, and the largest it grows to.
+const (
+	initialLogWindow = 10000
+	maxLogWindow     = 1000000
+)
+
+// LogIterator walks through the event logs of a block range in order, one chunk of blocks at a time.
+type LogIterator struct {
+	ctx             context.Context
+	c               *Client
+	contractAddress Address
+	topics          Topics
+	block           BlockRangeAdv
+	resolved        bool
+	cursor          int64
+	to              int64
+	window          int64
+	items           []EventLog
+	pos             int
+	cur             EventLog
+	err             error
+}
+
+// Iterate over every event log in block, splitting the range into chunks small enough to stay below the 1,000 logs limit.
+// Chunks shrink when they come back full and grow when they are sparse. ToLatest is resolved to the current head block first.
+func (c *Client) GetLogsIterator(ctx context.Context, block BlockRangeAdv, contractAddress Address, topics Topics) *LogIterator {
+	return &LogIterator{
+		ctx:             ctx,
+		c:               c,
+		contractAddress: contractAddress,
+		topics:          topics,
+		block:           block,
+		window:          initialLogWindow,
+	}
+}
+
+// Get every event log in block, ordered by block number and log index. See GetLogsIterator.
+func (c *Client) GetLogsAll(block BlockRangeAdv, contractAddress Address, topics Topics) ([]EventLog, error) {
+	return c.GetLogsAllCtx(context.Background(), block, contractAddress, topics)
+}
+
+// GetLogsAllCtx is GetLogsAll with a context.
+func (c *Client) GetLogsAllCtx(ctx context.Context, block BlockRangeAdv, contractAddress Address, topics Topics) ([]EventLog, error) {
+	it := c.GetLogsIterator(ctx, block, contractAddress, topics)
+
+	var logs []EventLog
+	for it.Next() {
+		logs = append(logs, it.Value())
+	}
+	return logs, it.Err()
+}
+
+// Advance to the next event log. Returns false when there are no more logs or an error occurred.
+func (it *LogIterator) Next() bool {
+	for it.pos >= len(it.items) {
+		if it.err != nil {
+			return false
+		}
+
+		if !it.resolved {
+			if it.err = it.resolve(); it.err != nil {
+				return false
+			}
+		}
+
+		if it.cursor > it.to {
+			return false
+		}
+
+		if it.err = it.fetch(); it.err != nil {
+			return false
+		}
+	}
+
+	it.cur = it.items[it.pos]
+	it.pos++
+	return true
+}
+
+// Current event log.
+func (it *LogIterator) Value() EventLog {
+	return it.cur
+}
+
+// Error that stopped the iteration, nil if it ran to the end.
+func (it *LogIterator) Err() error {
+	return it.err
+}
+
+func (it *LogIterator) resolve() error {
+	if it.block.FromBlock != nil {
+		it.cursor = it.block.FromBlock.Int64()
+	}
+
+	if it.block.ToLatest || it.block.ToBlock == nil {
+		latest, err := it.c.latestBlock(it.ctx)
+		if err != nil {
+			return err
+		}
+		it.to = latest
+	} else {
+		it.to = it.block.ToBlock.Int64()
+	}
+
+	it.resolved = true
+	return nil
+}
+
+// Load the next chunk that is not saturated.
+func (it *LogIterator) fetch() error {
+	for {
+		if err := it.ctx.Err(); err != nil {
+			return err
+		}
+
+		end := it.cursor + it.window - 1
+		if end > it.to || end < it.cursor {
+			end = it.to
+		}
+
+		block := BlockRangeAdv{FromBlock: big.NewInt(it.cursor), ToBlock: big.NewInt(end)}
+		logs, err := it.c.GetLogsCtx(it.ctx, block, it.contractAddress, it.topics)
+		if err != nil {
+			return err
+		}
+
+		if len(logs) >= logCap {
+			if it.cursor == end {
+				return fmt.Errorf("%w: %d", ErrResultCap, it.cursor)
+			}
+			// shrink below the saturated size so the next try fits
+			it.window = (end - it.cursor + 1) / 2
+			continue
+		}
+
+		if len(logs) < logCap/4 && it.window < maxLogWindow {
+			it.window *= 2
+			if it.window > maxLogWindow {
+				it.window = maxLogWindow
+			}
+		}
+		it.cursor = end + 1
+		it.items = uniqueLogs(logs)
+		it.pos = 0
+		return nil
+	}
+}
+
+// Drop duplicate logs and order the rest by block number and log index.
+// Chunks never overlap, so duplicates can only come from within one chunk.
+func uniqueLogs(logs []EventLog) []EventLog {
+	seen := make(map[logKey]bool, len(logs))
+	result := make([]EventLog, 0, len(logs))
+	for _, l := range logs {
+		key := logKey{l.TransactionHash, l.LogIndex}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, l)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if c := compareBigInt(result[i].BlockNumber, result[j].BlockNumber); c != 0 {
+			return c < 0
+		}
+		return result[i].LogIndex < result[j].LogIndex
+	})
+	return result
+}
@@ -0,0 +1,344 @@
+package celoexplorer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBisectRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int64 // position of every item, a window holding 3 or more is full
+		from    int64
+		to      int64
+		wantErr error
+	}{
+		{"fits", []int64{1, 5}, 0, 9, nil},
+		{"empty", nil, 0, 9, nil},
+		{"split once", []int64{1, 2, 7, 8}, 0, 9, nil},
+		{"split deep", []int64{4, 4, 5, 5, 6, 6, 7}, 0, 99, nil},
+		{"single point", []int64{1, 2, 3, 3, 3}, 0, 9, ErrResultCap},
+		{"single point range", []int64{3, 3, 3}, 3, 3, ErrResultCap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			var windows [][2]int64
+			err := bisectRange(context.Background(), tt.from, tt.to, func(ctx context.Context, from, to int64) (bool, error) {
+				windows = append(windows, [2]int64{from, to})
+				var in []int64
+				for _, x := range tt.items {
+					if x >= from && x <= to {
+						in = append(in, x)
+					}
+				}
+				if len(in) >= 3 {
+					return true, nil
+				}
+				got = append(got, in...)
+				return false, nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.items) {
+				t.Errorf("kept %v, want %v", got, tt.items)
+			}
+			for i, w := range windows {
+				if w[0] > w[1] || w[0] < tt.from || w[1] > tt.to {
+					t.Errorf("window %d is %v, outside [%d, %d]", i, w, tt.from, tt.to)
+				}
+			}
+		})
+	}
+}
+
+func TestBisectRangeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := bisectRange(ctx, 0, 9, func(ctx context.Context, from, to int64) (bool, error) {
+		t.Fatal("fetched with a cancelled context")
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+}
+
+// Fake txlist and tokentx endpoints over a chain where rowsIn tells how many transactions each block has.
+// Like Blockscout, they return at most offset rows.
+type txServer struct {
+	rowsIn func(block int64) int
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (s *txServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	from, _ := strconv.ParseInt(q.Get("startblock"), 10, 64)
+	to, _ := strconv.ParseInt(q.Get("endblock"), 10, 64)
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+
+	var rows []string
+	for b := from; b <= to && len(rows) < offset; b++ {
+		for i := 0; i < s.rowsIn(b) && len(rows) < offset; i++ {
+			switch q.Get("action") {
+			case "txlist":
+				rows = append(rows, fmt.Sprintf(`{"blockHash":"0x%064x","blockNumber":"%d","confirmations":"1","contractAddress":"","cumulativeGasUsed":"21000","feeCurrency":"","from":"0x471ece3750da237f93b8e339c536989b8978a438","gas":"21000","gasPrice":"1","gasUsed":"21000","gatewayFee":"0","gatewayFeeRecipient":"","hash":"0x%032x%032x","input":"0x","isError":"0","nonce":"%d","timeStamp":"1600000000","to":"0x471ece3750da237f93b8e339c536989b8978a438","transactionIndex":"%d","txreceipt_status":"1","value":"1"}`, b, b, b, i, i, i))
+			case "tokentx":
+				rows = append(rows, fmt.Sprintf(`{"blockHash":"0x%064x","blockNumber":"%d","confirmations":"1","contractAddress":"0x765de816845861e75a25fca122bb6898b8b1282a","cumulativeGasUsed":"21000","from":"0x471ece3750da237f93b8e339c536989b8978a438","gas":"21000","gasPrice":"1","gasUsed":"21000","hash":"0x%032x%032x","input":"0x","logIndex":"%d","nonce":"%d","timeStamp":"1600000000","to":"0x471ece3750da237f93b8e339c536989b8978a438","tokenDecimal":"18","tokenName":"Celo Dollar","tokenSymbol":"cUSD","transactionIndex":"0","value":"1"}`, b, b, b, i, i, i))
+			}
+		}
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprint(w, recordedEmptyResults["account."+q.Get("action")])
+		return
+	}
+	fmt.Fprintf(w, `{"message":"OK","result":[%s],"status":"1"}`, strings.Join(rows, ","))
+}
+
+func newTxClient(t *testing.T, s *txServer) *Client {
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestTxListAll(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsIn       func(block int64) int
+		wantRows     int
+		wantRequests int
+		wantErr      error
+	}{
+		// a window holding exactly resultCap rows may have been cut off, so it is split as well
+		{"exactly the cap", func(int64) int { return 10 }, resultCap, 3, nil},
+		{"below the cap", func(int64) int { return 9 }, resultCap * 9 / 10, 1, nil},
+		{"over the cap", func(int64) int { return 15 }, resultCap * 3 / 2, 3, nil},
+		{"single block at the cap", func(b int64) int {
+			if b == 500 {
+				return resultCap
+			}
+			return 0
+		}, 0, 0, ErrResultCap},
+	}
+
+	address := MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438")
+	block := &BlockRange{StartBlock: big.NewInt(0), EndBlock: big.NewInt(999)}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &txServer{rowsIn: tt.rowsIn}
+			txs, err := newTxClient(t, s).TxListAll(address, block, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(txs) != tt.wantRows {
+				t.Errorf("got %d transactions, want %d", len(txs), tt.wantRows)
+			}
+			if s.requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", s.requests, tt.wantRequests)
+			}
+			for i := 1; i < len(txs); i++ {
+				a, b := txs[i-1], txs[i]
+				c := compareBigInt(a.BlockNumber, b.BlockNumber)
+				if c > 0 || c == 0 && a.TransactionIndex >= b.TransactionIndex {
+					t.Fatalf("transaction %d at block %s index %d is out of order", i, b.BlockNumber, b.TransactionIndex)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenTxAll(t *testing.T) {
+	s := &txServer{rowsIn: func(b int64) int {
+		if b < 500 {
+			return 20
+		}
+		return 0
+	}}
+	address := MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438")
+	block := &BlockRange{StartBlock: big.NewInt(0), EndBlock: big.NewInt(999)}
+
+	transfers, err := newTxClient(t, s).TokenTxAll(address, nil, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// [0, 999] and [0, 499] are full, [0, 249], [250, 499] and [500, 999] are not
+	if len(transfers) != resultCap {
+		t.Errorf("got %d transfers, want %d", len(transfers), resultCap)
+	}
+	if s.requests != 5 {
+		t.Errorf("made %d requests, want 5", s.requests)
+	}
+	for i := 1; i < len(transfers); i++ {
+		a, b := transfers[i-1], transfers[i]
+		c := compareBigInt(a.BlockNumber, b.BlockNumber)
+		if c > 0 || c == 0 && a.LogIndex >= b.LogIndex {
+			t.Fatalf("transfer %d at block %s log %d is out of order", i, b.BlockNumber, b.LogIndex)
+		}
+	}
+}
+
+// Fake getLogs endpoint over a chain where logsIn tells how many logs each block has.
+// It records the block windows it was asked for and, like Blockscout, returns at most logCap logs.
+type logServer struct {
+	logsIn func(block int64) int
+
+	mu      sync.Mutex
+	windows [][2]int64
+}
+
+func (s *logServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	if q.Get("action") == "eth_block_number" {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x0"}`)
+		return
+	}
+
+	from, _ := strconv.ParseInt(q.Get("fromBlock"), 10, 64)
+	to, _ := strconv.ParseInt(q.Get("toBlock"), 10, 64)
+	s.mu.Lock()
+	s.windows = append(s.windows, [2]int64{from, to})
+	s.mu.Unlock()
+
+	var logs []string
+	for b := from; b <= to && len(logs) < logCap; b++ {
+		for i := 0; i < s.logsIn(b) && len(logs) < logCap; i++ {
+			logs = append(logs, fmt.Sprintf(`{"address":"0x471ece3750da237f93b8e339c536989b8978a438","blockNumber":"0x%x","data":"0x","gasPrice":"0x1","gasUsed":"0x5208","logIndex":"0x%x","timeStamp":"0x5f5e1000","topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],"transactionHash":"0x%064x","transactionIndex":"0x0"}`, b, i, b))
+		}
+	}
+
+	if len(logs) == 0 {
+		fmt.Fprint(w, `{"message":"No logs found","result":[],"status":"0"}`)
+		return
+	}
+	fmt.Fprintf(w, `{"message":"OK","result":[%s],"status":"1"}`, strings.Join(logs, ","))
+}
+
+func newLogIterator(t *testing.T, s *logServer, from, to int64) *LogIterator {
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL + "/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := BlockRangeAdv{FromBlock: big.NewInt(from), ToBlock: big.NewInt(to)}
+	return c.GetLogsIterator(context.Background(), block, MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438"), Topics{})
+}
+
+func TestLogIteratorGrowsOnSparseChunks(t *testing.T) {
+	s := &logServer{logsIn: func(int64) int { return 0 }}
+	it := newLogIterator(t, s, 0, 5000000)
+
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	var prev int64
+	for i, w := range s.windows {
+		size := w[1] - w[0] + 1
+		if size > maxLogWindow {
+			t.Fatalf("window %d spans %d blocks, more than %d", i, size, maxLogWindow)
+		}
+		if i > 0 && size < prev && w[1] != 5000000 {
+			t.Errorf("window %d shrank from %d to %d on an empty chain", i, prev, size)
+		}
+		prev = size
+	}
+	if first := s.windows[0]; first[1]-first[0]+1 != initialLogWindow {
+		t.Errorf("first window spans %d blocks, want %d", first[1]-first[0]+1, initialLogWindow)
+	}
+	if it.window != maxLogWindow {
+		t.Errorf("window grew to %d, want the maximum of %d", it.window, maxLogWindow)
+	}
+}
+
+func TestLogIteratorShrinksOnSaturatedChunks(t *testing.T) {
+	// 2,000 logs in blocks 5,000 to 5,999, nothing elsewhere
+	s := &logServer{logsIn: func(b int64) int {
+		if b >= 5000 && b < 6000 {
+			return 2
+		}
+		return 0
+	}}
+	it := newLogIterator(t, s, 0, 20000)
+
+	var n int
+	var prev EventLog
+	for it.Next() {
+		l := it.Value()
+		c := compareBigInt(l.BlockNumber, prev.BlockNumber)
+		if n > 0 && (c < 0 || c == 0 && l.LogIndex <= prev.LogIndex) {
+			t.Fatalf("log %d at block %s index %d is out of order", n, l.BlockNumber, l.LogIndex)
+		}
+		prev = l
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2000 {
+		t.Errorf("got %d logs, want 2000", n)
+	}
+
+	// a saturated window is tried again from the same block with half its size
+	var shrunk bool
+	for i := 1; i < len(s.windows); i++ {
+		a, b := s.windows[i-1], s.windows[i]
+		if a[0] == b[0] && b[1]-b[0]+1 == (a[1]-a[0]+1)/2 {
+			shrunk = true
+		}
+	}
+	if !shrunk {
+		t.Errorf("no window was halved: %v", s.windows)
+	}
+}
+
+func TestLogIteratorSingleBlockCap(t *testing.T) {
+	s := &logServer{logsIn: func(b int64) int {
+		if b == 7 {
+			return logCap
+		}
+		return 0
+	}}
+	it := newLogIterator(t, s, 0, 100)
+
+	for it.Next() {
+	}
+	if err := it.Err(); !errors.Is(err, ErrResultCap) {
+		t.Errorf("got error %v, want ErrResultCap", err)
+	}
+}
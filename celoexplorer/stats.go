@@ -0,0 +1,195 @@
+package celoexplorer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// Get ERC-20 or ERC-721 token total supply by contract address, in the token's smallest unit.
+func (c *Client) TokenSupply(contractAddress Address) (*big.Int, error) {
+	return c.TokenSupplyCtx(context.Background(), contractAddress)
+}
+
+// TokenSupplyCtx is TokenSupply with a context.
+func (c *Client) TokenSupplyCtx(ctx context.Context, contractAddress Address) (*big.Int, error) {
+	supply, err := c.req.TokenSupplyCtx(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("TokenSupply")
+	return d.bigInt("result", string(supply), 10), d.err
+}
+
+// Get total supply in Wei from exchange.
+func (c *Client) EthSupplyExchange() (*big.Int, error) {
+	return c.EthSupplyExchangeCtx(context.Background())
+}
+
+// EthSupplyExchangeCtx is EthSupplyExchange with a context.
+func (c *Client) EthSupplyExchangeCtx(ctx context.Context) (*big.Int, error) {
+	supply, err := c.req.EthSupplyExchangeCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("EthSupplyExchange")
+	return d.bigInt("result", string(supply), 10), d.err
+}
+
+// Get total supply in Wei from DB.
+func (c *Client) EthSupply() (*big.Int, error) {
+	return c.EthSupplyCtx(context.Background())
+}
+
+// EthSupplyCtx is EthSupply with a context.
+func (c *Client) EthSupplyCtx(ctx context.Context) (*big.Int, error) {
+	supply, err := c.req.EthSupplyCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("EthSupply")
+	return d.bigInt("result", string(supply), 10), d.err
+}
+
+// Get total coin supply from DB minus burnt number, in Wei.
+// The explorer reports it in whole coins as a floating point number, so digits beyond its precision are zero.
+func (c *Client) CoinSupply() (*big.Int, error) {
+	return c.CoinSupplyCtx(context.Background())
+}
+
+// CoinSupplyCtx is CoinSupply with a context.
+func (c *Client) CoinSupplyCtx(ctx context.Context) (*big.Int, error) {
+	supply, err := c.req.CoinSupplyCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wei, _ := new(big.Float).Mul(big.NewFloat(float64(supply)), big.NewFloat(1e18)).Int(nil)
+	return wei, nil
+}
+
+type Price struct {
+	// Price of one CELO in BTC.
+	BTC          *big.Rat
+	BTCTimestamp time.Time
+	// Price of one CELO in USD.
+	USD          *big.Rat
+	USDTimestamp time.Time
+}
+
+// Get latest price in USD and BTC.
+func (c *Client) EthPrice() (Price, error) {
+	return c.EthPriceCtx(context.Background())
+}
+
+// EthPriceCtx is EthPrice with a context.
+func (c *Client) EthPriceCtx(ctx context.Context) (Price, error) {
+	price, err := c.req.EthPriceCtx(ctx)
+	if err != nil {
+		return Price{}, err
+	}
+
+	d := c.decoder("EthPrice")
+	return Price{
+		BTC:          d.decimal("ethbtc", price.Ethbtc),
+		BTCTimestamp: d.unix("ethbtc_timestamp", price.EthbtcTimestamp, 10),
+		USD:          d.decimal("ethusd", price.Ethusd),
+		USDTimestamp: d.unix("ethusd_timestamp", price.EthusdTimestamp, 10),
+	}, d.err
+}
+
+// Get estimated total number of transactions.
+func (c *Client) TotalTransactions() (*big.Int, error) {
+	return c.TotalTransactionsCtx(context.Background())
+}
+
+// TotalTransactionsCtx is TotalTransactions with a context.
+func (c *Client) TotalTransactionsCtx(ctx context.Context) (*big.Int, error) {
+	total, err := c.req.TotalTransactionsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d := c.decoder("TotalTransactions")
+	return d.bigInt("result", string(total), 10), d.err
+}
+
+// Stats is a snapshot of the network wide figures of the explorer.
+type Stats struct {
+	// Total supply in Wei from DB.
+	EthSupply *big.Int
+	// Total supply in Wei from exchange.
+	EthSupplyExchange *big.Int
+	// Total supply minus burnt coins, in Wei.
+	CoinSupply        *big.Int
+	Price             Price
+	TotalTransactions *big.Int
+	BlockNumber       *big.Int
+}
+
+// Get the supplies, price, transaction count and latest block of the network.
+func (c *Client) NetworkStats() (Stats, error) {
+	return c.NetworkStatsCtx(context.Background())
+}
+
+// NetworkStatsCtx is NetworkStats with a context.
+// The figures are fetched concurrently; the first failure cancels the remaining requests and is returned.
+func (c *Client) NetworkStatsCtx(ctx context.Context) (Stats, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		stats Stats
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	run := func(fetch func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fetch(); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	// every fetch writes its own field, so they need no locking
+	run(func() (err error) {
+		stats.EthSupply, err = c.EthSupplyCtx(ctx)
+		return err
+	})
+	run(func() (err error) {
+		stats.EthSupplyExchange, err = c.EthSupplyExchangeCtx(ctx)
+		return err
+	})
+	run(func() (err error) {
+		stats.CoinSupply, err = c.CoinSupplyCtx(ctx)
+		return err
+	})
+	run(func() (err error) {
+		stats.Price, err = c.EthPriceCtx(ctx)
+		return err
+	})
+	run(func() (err error) {
+		stats.TotalTransactions, err = c.TotalTransactionsCtx(ctx)
+		return err
+	})
+	run(func() (err error) {
+		stats.BlockNumber, err = c.EthBlockNumberCtx(ctx)
+		return err
+	})
+	wg.Wait()
+
+	if first != nil {
+		return Stats{}, first
+	}
+	return stats, nil
+}
@@ -0,0 +1,191 @@
+package celoexplorer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Returned, wrapped in a ValidationError, when an argument is rejected before any request is sent.
+var ErrInvalidArgument = errors.New("celoexplorer: invalid argument")
+
+// ValidationError describes an argument that would have made the request fail. It matches ErrInvalidArgument with errors.Is.
+type ValidationError struct {
+	// RequestClient method, e.g. "GetLogs".
+	Method string
+	// Offending parameter or field, e.g. "topics.Opr01".
+	Param  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("celoexplorer: %s: invalid %s: %s", e.Method, e.Param, e.Reason)
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// First failed check of method, nil if all passed.
+func validate(method string, checks ...*ValidationError) error {
+	for _, e := range checks {
+		if e != nil {
+			e.Method = method
+			return e
+		}
+	}
+	return nil
+}
+
+func invalid(param, format string, a ...interface{}) *ValidationError {
+	return &ValidationError{Param: param, Reason: fmt.Sprintf(format, a...)}
+}
+
+func checkHash(param string, hash Hash) *ValidationError {
+	if hash.IsZero() {
+		return invalid(param, "hash is required")
+	}
+	return nil
+}
+
+func checkHashOrAddress(txhash Hash, address *Address) *ValidationError {
+	if txhash.IsZero() && address == nil {
+		return invalid("txhash", "a transaction hash or an address is required")
+	}
+	return nil
+}
+
+// The explorer accepts up to 20 addresses at once.
+func checkAddressMulti(param string, address []Address) *ValidationError {
+	switch {
+	case len(address) == 0:
+		return invalid(param, "at least one address is required")
+	case len(address) > 20:
+		return invalid(param, "at most 20 addresses are allowed, got %d", len(address))
+	}
+	return nil
+}
+
+func checkBlockNumber(param string, number *big.Int, required bool) *ValidationError {
+	if number == nil {
+		if required {
+			return invalid(param, "block number is required")
+		}
+		return nil
+	}
+
+	if number.Sign() < 0 {
+		return invalid(param, "block number %s is negative", number)
+	}
+	return nil
+}
+
+func checkPageRange(page *PageRange) *ValidationError {
+	if page == nil {
+		return nil
+	}
+
+	switch {
+	case page.Page < 1:
+		return invalid("page.Page", "page %d is less than 1", page.Page)
+	case page.Offset < 1:
+		return invalid("page.Offset", "offset %d is less than 1", page.Offset)
+	}
+	return nil
+}
+
+func checkBlockRange(block *BlockRange) *ValidationError {
+	if block == nil {
+		return nil
+	}
+
+	if e := checkBlockNumber("block.StartBlock", block.StartBlock, false); e != nil {
+		return e
+	}
+	if e := checkBlockNumber("block.EndBlock", block.EndBlock, false); e != nil {
+		return e
+	}
+
+	if block.StartBlock != nil && block.EndBlock != nil && block.StartBlock.Cmp(block.EndBlock) > 0 {
+		return invalid("block", "start block %s is after end block %s", block.StartBlock, block.EndBlock)
+	}
+	return nil
+}
+
+func checkBlockRangeAdv(block BlockRangeAdv) *ValidationError {
+	if e := checkBlockNumber("block.FromBlock", block.FromBlock, true); e != nil {
+		return e
+	}
+	if block.ToLatest {
+		return nil
+	}
+
+	if e := checkBlockNumber("block.ToBlock", block.ToBlock, true); e != nil {
+		return e
+	}
+	if block.FromBlock.Cmp(block.ToBlock) > 0 {
+		return invalid("block", "from block %s is after to block %s", block.FromBlock, block.ToBlock)
+	}
+	return nil
+}
+
+func checkTimeRange(timeRange *TimeRange) *ValidationError {
+	if timeRange != nil && timeRange.Start.After(timeRange.End) {
+		return invalid("timeRange", "start %v is after end %v", timeRange.Start, timeRange.End)
+	}
+	return nil
+}
+
+// Every pair of topics that are set needs a valid operator, and a query needs an address or topic0.
+func checkTopics(contractAddress Address, topics Topics) *ValidationError {
+	present := topics.present()
+
+	for i := range present {
+		if present[i] == nil {
+			continue
+		}
+
+		for j := i + 1; j < len(present); j++ {
+			if present[j] == nil {
+				continue
+			}
+
+			param := fmt.Sprintf("topics.Opr%d%d", i, j)
+			opr := topics.operator(i, j)
+			if opr == nil {
+				return invalid(param, "operator is required when topic%d and topic%d are both set", i, j)
+			}
+			if *opr != TopicOperator.And && *opr != TopicOperator.Or {
+				return invalid(param, "unknown operator %q", string(*opr))
+			}
+		}
+	}
+
+	if topics.Topic0.IsZero() && contractAddress.IsZero() {
+		return invalid("topics.Topic0", "topic0 is required when no address is given")
+	}
+	return nil
+}
+
+func checkContractInfo(contract ContractInfo) *ValidationError {
+	switch {
+	case contract.AddressHash.IsZero():
+		return invalid("contract.AddressHash", "address is required")
+	case contract.Name == "":
+		return invalid("contract.Name", "name is required")
+	case contract.CompilerVersion == "":
+		return invalid("contract.CompilerVersion", "compiler version is required")
+	case contract.ContractSourceCode == "":
+		return invalid("contract.ContractSourceCode", "source code is required")
+	case contract.OptimizationRuns != nil && *contract.OptimizationRuns < 0:
+		return invalid("contract.OptimizationRuns", "%d is negative", *contract.OptimizationRuns)
+	}
+	return nil
+}
+
+func checkIndex(index *int) *ValidationError {
+	if index != nil && *index < 0 {
+		return invalid("index", "%d is negative", *index)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package celoexplorer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckTopics(t *testing.T) {
+	address := MustParseAddress("0x471ece3750da237f93b8e339c536989b8978a438")
+	h := MustParseHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	and, bad := TopicOperator.And, topicOperatorType("xor")
+
+	tests := []struct {
+		name      string
+		address   Address
+		topics    Topics
+		wantParam string
+	}{
+		{"address only", address, Topics{}, ""},
+		{"topic0 only", Address{}, Topics{Topic0: h}, ""},
+		{"topic1 with address", address, Topics{Topic1: &h}, ""},
+		{"nothing", Address{}, Topics{}, "topics.Topic0"},
+		{"topic1 without topic0 or address", Address{}, Topics{Topic1: &h}, "topics.Topic0"},
+		{"two topics with operator", Address{}, Topics{Topic0: h, Topic2: &h, Opr02: &and}, ""},
+		{"two topics without operator", Address{}, Topics{Topic0: h, Topic2: &h}, "topics.Opr02"},
+		{"unknown operator", address, Topics{Topic1: &h, Topic3: &h, Opr13: &bad}, "topics.Opr13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate("GetLogs", checkTopics(tt.address, tt.topics))
+			if tt.wantParam == "" {
+				if err != nil {
+					t.Fatalf("got error %v, want none", err)
+				}
+				return
+			}
+
+			var v *ValidationError
+			if !errors.As(err, &v) || !errors.Is(err, ErrInvalidArgument) {
+				t.Fatalf("got error %v, want *ValidationError", err)
+			}
+			if v.Param != tt.wantParam {
+				t.Errorf("got param %s, want %s", v.Param, tt.wantParam)
+			}
+		})
+	}
+}